@@ -0,0 +1,45 @@
+// fakezoom 启动本地 Zoom API 模拟服务，配合 ZOOM_OAUTH_BASE_URL/ZOOM_API_BASE_URL 进行离线开发
+package main
+
+import (
+	"flag"
+	"net/http"
+
+	"zoom-app-server/fakezoom"
+	"zoom-app-server/utils/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	addr := flag.String("addr", ":9090", "监听地址")
+	accountID := flag.String("account-id", "", "期望的 account_id，为空时不校验")
+	clientID := flag.String("client-id", "", "期望的 client_id，为空时不校验")
+	clientSecret := flag.String("client-secret", "", "期望的 client_secret")
+	hostEmail := flag.String("host-email", "host@example.com", "默认主持人邮箱")
+	webhookURL := flag.String("webhook-url", "", "Webhook 推送地址，如 http://localhost:8080/api/webhooks/zoom")
+	webhookSecret := flag.String("webhook-secret", "", "Webhook 签名密钥，与 ZOOM_WEBHOOK_SECRET_TOKEN 一致")
+	flag.Parse()
+
+	logger.InitLogger(&logger.LogConfig{Level: "info", Format: "text", Output: "stdout"})
+
+	server := fakezoom.New(fakezoom.Config{
+		AccountID:     *accountID,
+		ClientID:      *clientID,
+		ClientSecret:  *clientSecret,
+		HostEmail:     *hostEmail,
+		WebhookURL:    *webhookURL,
+		WebhookSecret: *webhookSecret,
+	})
+
+	logger.WithFields(logrus.Fields{
+		"addr":        *addr,
+		"host_email":  *hostEmail,
+		"webhook_url": *webhookURL,
+	}).Info("Fake Zoom server starting")
+	logger.Infof("Set ZOOM_OAUTH_BASE_URL=http://localhost%s and ZOOM_API_BASE_URL=http://localhost%s/v2", *addr, *addr)
+
+	if err := http.ListenAndServe(*addr, server); err != nil {
+		logger.WithError(err).Fatal("Fake Zoom server stopped")
+	}
+}
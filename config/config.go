@@ -4,30 +4,91 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
+// 签名格式
+const (
+	SignatureFormatSDK    = "sdk"    // Meeting SDK 格式
+	SignatureFormatLegacy = "legacy" // 旧版 JWT 应用格式（已被Zoom弃用）
+)
+
 // Config 存储应用程序配置
 type Config struct {
 	ZoomAPIKey    string
 	ZoomAPISecret string
 	Port          string
+	// HTTP 服务器配置（秒）
+	ServerReadTimeout       int
+	ServerReadHeaderTimeout int
+	ServerWriteTimeout      int // 需大于 ZoomRequestTimeout，否则慢请求的响应会被截断
+	ServerIdleTimeout       int
+	ServerMaxHeaderBytes    int // 请求头最大字节数
+	ShutdownTimeout         int // 收到退出信号后等待进行中请求完成的最长时间
+	// Meeting SDK 签名配置
+	ZoomSDKKey             string
+	ZoomSDKSecret          string
+	ZoomSignatureFormat    string
+	ZoomSignatureTTL       int // 签名有效期（秒），限制在1800-172800之间
+	ZoomSignatureClockSkew int // iat 向前偏移的秒数，容忍客户端时钟误差
+	// Video SDK 配置
+	ZoomVideoSDKKey      string
+	ZoomVideoSDKSecret   string
+	ZoomVideoSDKTokenTTL int // 会话令牌有效期（秒），限制在1800-172800之间
 	// Server-To-Server OAuth 配置
 	ZoomAccountID    string
 	ZoomClientID     string
 	ZoomClientSecret string
+	// OAuth令牌在过期前多少秒开始刷新
+	ZoomTokenRefreshBefore int
+	// Zoom 接口地址，可指向 fakezoom 等替身服务
+	ZoomOAuthBaseURL string
+	ZoomAPIBaseURL   string
+	// Zoom HTTP 客户端配置
+	ZoomHTTPTimeout     int // 单次请求超时（秒）
+	ZoomRequestTimeout  int // 一次调用（含重试和令牌刷新）的总期限（秒）
+	ZoomMaxRetries      int // 网络错误、5xx、429 的最大重试次数
+	ZoomRetryMaxWait    int // 429 时愿意等待的最长时间（秒），超过则直接返回错误
+	ZoomRateLimitLight  int // 各限流类别每秒请求数，0表示不限流
+	ZoomRateLimitMedium int
+	ZoomRateLimitHeavy  int
+	// DooTask用户ID到Zoom用户(ID或邮箱)的映射，未映射的用户使用DooTask邮箱
+	ZoomUserMapping map[int]string
+	// Webhook 配置
+	ZoomWebhookSecretToken string
+	ZoomWebhookMaxSkew     int // 允许的请求时间戳偏差（秒）
 	// 功能开关
 	DisableJoinMeeting bool
 	// DooTask 验证配置
 	DooTaskURL         string
 	DooTaskTimeout     int
 	DisableDooTaskAuth bool
+	// DooTask token验证结果缓存
+	DooTaskCacheTTL         int // 有效token缓存时间（秒），0表示不缓存
+	DooTaskCacheNegativeTTL int // 无效token缓存时间（秒）
+	DooTaskCacheSize        int // 最多缓存的token数量
+	// 本地存储配置
+	DBPath string
+	// 管理接口令牌，通过 X-Admin-Token 请求头传入；为空时只允许DooTask管理员访问
+	AdminToken string
+	// OpenTelemetry 链路追踪配置
+	OTelExporterEndpoint string  // OTLP/HTTP 地址，如 http://localhost:4318，自动追加 /v1/traces，为空时不导出
+	OTelServiceName      string  // 上报的服务名
+	OTelSampleRatio      float64 // 采样比例 0-1
 	// 日志配置
 	LogLevel    string
 	LogFormat   string
 	LogOutput   string
 	LogFilePath string
+	// 日志文件滚动配置
+	LogMaxSize    int  // 单个日志文件最大大小(MB)
+	LogMaxBackups int  // 保留的旧日志文件数量
+	LogMaxAge     int  // 保留日志文件的最大天数
+	LogCompress   bool // 是否gzip压缩旧日志文件
+	// 日志中额外需要屏蔽的正则表达式
+	LogRedactPatterns []string
 }
 
 // LoadConfig 从环境变量加载配置
@@ -41,25 +102,100 @@ func LoadConfig() *Config {
 		ZoomAPIKey:    getEnv("ZOOM_API_KEY", ""),
 		ZoomAPISecret: getEnv("ZOOM_API_SECRET", ""),
 		Port:          getEnv("PORT", "8080"),
+		// HTTP 服务器配置
+		ServerReadTimeout:       getEnvAsInt("SERVER_READ_TIMEOUT", 15),
+		ServerReadHeaderTimeout: getEnvAsInt("SERVER_READ_HEADER_TIMEOUT", 5),
+		ServerWriteTimeout:      getEnvAsInt("SERVER_WRITE_TIMEOUT", 90),
+		ServerIdleTimeout:       getEnvAsInt("SERVER_IDLE_TIMEOUT", 120),
+		ServerMaxHeaderBytes:    getEnvAsInt("SERVER_MAX_HEADER_BYTES", 1<<20),
+		ShutdownTimeout:         getEnvAsInt("SHUTDOWN_TIMEOUT", 30),
+		// Meeting SDK 签名配置
+		ZoomSDKKey:             getEnv("ZOOM_SDK_KEY", ""),
+		ZoomSDKSecret:          getEnv("ZOOM_SDK_SECRET", ""),
+		ZoomSignatureFormat:    getEnv("ZOOM_SIGNATURE_FORMAT", ""),
+		ZoomSignatureTTL:       getEnvAsInt("ZOOM_SIGNATURE_TTL", 7200),
+		ZoomSignatureClockSkew: getEnvAsInt("ZOOM_SIGNATURE_CLOCK_SKEW", 30),
+		// Video SDK 配置
+		ZoomVideoSDKKey:      getEnv("ZOOM_VIDEO_SDK_KEY", ""),
+		ZoomVideoSDKSecret:   getEnv("ZOOM_VIDEO_SDK_SECRET", ""),
+		ZoomVideoSDKTokenTTL: getEnvAsInt("ZOOM_VIDEO_SDK_TOKEN_TTL", 7200),
 		// Server-To-Server OAuth 配置
-		ZoomAccountID:    getEnv("ZOOM_ACCOUNT_ID", ""),
-		ZoomClientID:     getEnv("ZOOM_CLIENT_ID", ""),
-		ZoomClientSecret: getEnv("ZOOM_CLIENT_SECRET", ""),
+		ZoomAccountID:          getEnv("ZOOM_ACCOUNT_ID", ""),
+		ZoomClientID:           getEnv("ZOOM_CLIENT_ID", ""),
+		ZoomClientSecret:       getEnv("ZOOM_CLIENT_SECRET", ""),
+		ZoomTokenRefreshBefore: getEnvAsInt("ZOOM_TOKEN_REFRESH_BEFORE", 300),
+		// Zoom 接口地址
+		ZoomOAuthBaseURL: getEnv("ZOOM_OAUTH_BASE_URL", "https://zoom.us"),
+		ZoomAPIBaseURL:   getEnv("ZOOM_API_BASE_URL", "https://api.zoom.us/v2"),
+		// Zoom HTTP 客户端配置
+		ZoomHTTPTimeout:     getEnvAsInt("ZOOM_HTTP_TIMEOUT", 30),
+		ZoomRequestTimeout:  getEnvAsInt("ZOOM_REQUEST_TIMEOUT", 60),
+		ZoomMaxRetries:      getEnvAsInt("ZOOM_MAX_RETRIES", 3),
+		ZoomRetryMaxWait:    getEnvAsInt("ZOOM_RETRY_MAX_WAIT", 10),
+		ZoomRateLimitLight:  getEnvAsInt("ZOOM_RATE_LIMIT_LIGHT", 30),
+		ZoomRateLimitMedium: getEnvAsInt("ZOOM_RATE_LIMIT_MEDIUM", 20),
+		ZoomRateLimitHeavy:  getEnvAsInt("ZOOM_RATE_LIMIT_HEAVY", 10),
+		ZoomUserMapping:     parseUserMapping(getEnv("ZOOM_USER_MAPPING", "")),
+		// Webhook 配置
+		ZoomWebhookSecretToken: getEnv("ZOOM_WEBHOOK_SECRET_TOKEN", ""),
+		ZoomWebhookMaxSkew:     getEnvAsInt("ZOOM_WEBHOOK_MAX_SKEW", 300),
 		// 功能开关
 		DisableJoinMeeting: getEnv("DISABLE_JOIN_MEETING", "false") == "true",
 		// DooTask 验证配置
 		DooTaskURL:         getEnv("DOOTASK_URL", "http://nginx"),
 		DooTaskTimeout:     getEnvAsInt("DOOTASK_TIMEOUT", 10),
 		DisableDooTaskAuth: getEnv("DISABLE_DOOTASK_AUTH", "false") == "true",
+		// DooTask token验证结果缓存
+		DooTaskCacheTTL:         getEnvAsInt("DOOTASK_CACHE_TTL", 60),
+		DooTaskCacheNegativeTTL: getEnvAsInt("DOOTASK_CACHE_NEGATIVE_TTL", 10),
+		DooTaskCacheSize:        getEnvAsInt("DOOTASK_CACHE_SIZE", 1000),
+		// 本地存储配置
+		DBPath: getEnv("DB_PATH", "data/zoom-app.db"),
+		// 管理接口配置
+		AdminToken: getEnv("ADMIN_TOKEN", ""),
+		// OpenTelemetry 链路追踪配置
+		OTelExporterEndpoint: getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+		OTelServiceName:      getEnv("OTEL_SERVICE_NAME", "zoom-app-server"),
+		OTelSampleRatio:      getEnvAsFloat("OTEL_SAMPLE_RATIO", 1),
 		// 日志配置
 		LogLevel:    getEnv("LOG_LEVEL", "info"),
 		LogFormat:   getEnv("LOG_FORMAT", "json"),
 		LogOutput:   getEnv("LOG_OUTPUT", "file"),
 		LogFilePath: getEnv("LOG_FILE_PATH", "logs/app.log"),
+		// 日志文件滚动配置
+		LogMaxSize:    getEnvAsInt("LOG_MAX_SIZE", 100),
+		LogMaxBackups: getEnvAsInt("LOG_MAX_BACKUPS", 7),
+		LogMaxAge:     getEnvAsInt("LOG_MAX_AGE", 30),
+		LogCompress:   getEnv("LOG_COMPRESS", "true") == "true",
+		// 多个正则以 ; 分隔
+		LogRedactPatterns: splitNonEmpty(getEnv("LOG_REDACT_PATTERNS", ""), ";"),
+	}
+	// 未指定签名格式时，配置了SDK密钥则使用Meeting SDK格式，否则沿用旧版格式
+	if config.ZoomSignatureFormat == "" {
+		if config.ZoomSDKKey != "" {
+			config.ZoomSignatureFormat = SignatureFormatSDK
+		} else {
+			config.ZoomSignatureFormat = SignatureFormatLegacy
+		}
+	}
+	if config.ZoomSignatureFormat != SignatureFormatSDK && config.ZoomSignatureFormat != SignatureFormatLegacy {
+		log.Fatalf("ZOOM_SIGNATURE_FORMAT must be %q or %q", SignatureFormatSDK, SignatureFormatLegacy)
+	}
+	// Meeting SDK 格式未单独配置密钥时使用 ZOOM_API_KEY/ZOOM_API_SECRET
+	if config.ZoomSDKKey == "" {
+		config.ZoomSDKKey = config.ZoomAPIKey
+	}
+	if config.ZoomSDKSecret == "" {
+		config.ZoomSDKSecret = config.ZoomAPISecret
 	}
+
 	if !config.DisableJoinMeeting {
 		// 验证必要的配置
-		if config.ZoomAPIKey == "" || config.ZoomAPISecret == "" {
+		if config.ZoomSignatureFormat == SignatureFormatSDK {
+			if config.ZoomSDKKey == "" || config.ZoomSDKSecret == "" {
+				log.Fatal("ZOOM_SDK_KEY and ZOOM_SDK_SECRET must be set")
+			}
+		} else if config.ZoomAPIKey == "" || config.ZoomAPISecret == "" {
 			log.Fatal("ZOOM_API_KEY and ZOOM_API_SECRET must be set")
 		}
 	}
@@ -81,6 +217,35 @@ func getEnv(key, defaultValue string) string {
 	return value
 }
 
+// parseUserMapping 解析 "DooTask用户ID:Zoom用户" 以逗号分隔的映射
+func parseUserMapping(value string) map[int]string {
+	mapping := make(map[int]string)
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		userID, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+		if err != nil || strings.TrimSpace(parts[1]) == "" {
+			log.Printf("Warning: invalid ZOOM_USER_MAPPING entry %q", pair)
+			continue
+		}
+		mapping[userID] = strings.TrimSpace(parts[1])
+	}
+	return mapping
+}
+
+// splitNonEmpty 按分隔符拆分字符串，去掉首尾空白和空项
+func splitNonEmpty(value, sep string) []string {
+	var items []string
+	for _, item := range strings.Split(value, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // getEnvAsInt 获取整数类型的环境变量，如果不存在或转换失败则返回默认值
 func getEnvAsInt(key string, defaultValue int) int {
 	value := os.Getenv(key)
@@ -92,3 +257,15 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// getEnvAsFloat 获取浮点类型的环境变量，如果不存在或转换失败则返回默认值
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+		return floatValue
+	}
+	return defaultValue
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"reflect"
+)
+
+// redactedValue 敏感配置项展示时的替换值
+const redactedValue = "[REDACTED]"
+
+// exposedFields 可以原样展示的配置项
+// 新增配置项时必须登记到 exposedFields 或 redactedFields，未登记的配置项一律按敏感项屏蔽
+var exposedFields = map[string]bool{
+	"Port":                    true,
+	"ServerReadTimeout":       true,
+	"ServerReadHeaderTimeout": true,
+	"ServerWriteTimeout":      true,
+	"ServerIdleTimeout":       true,
+	"ServerMaxHeaderBytes":    true,
+	"ShutdownTimeout":         true,
+	"ZoomSignatureFormat":     true,
+	"ZoomSignatureTTL":        true,
+	"ZoomSignatureClockSkew":  true,
+	"ZoomVideoSDKTokenTTL":    true,
+	"ZoomAccountID":           true,
+	"ZoomClientID":            true,
+	"ZoomTokenRefreshBefore":  true,
+	"ZoomOAuthBaseURL":        true,
+	"ZoomAPIBaseURL":          true,
+	"ZoomHTTPTimeout":         true,
+	"ZoomRequestTimeout":      true,
+	"ZoomMaxRetries":          true,
+	"ZoomRetryMaxWait":        true,
+	"ZoomRateLimitLight":      true,
+	"ZoomRateLimitMedium":     true,
+	"ZoomRateLimitHeavy":      true,
+	"ZoomUserMapping":         true,
+	"ZoomWebhookMaxSkew":      true,
+	"DisableJoinMeeting":      true,
+	"DooTaskURL":              true,
+	"DooTaskTimeout":          true,
+	"DisableDooTaskAuth":      true,
+	"DooTaskCacheTTL":         true,
+	"DooTaskCacheNegativeTTL": true,
+	"DooTaskCacheSize":        true,
+	"DBPath":                  true,
+	"OTelExporterEndpoint":    true,
+	"OTelServiceName":         true,
+	"OTelSampleRatio":         true,
+	"LogLevel":                true,
+	"LogFormat":               true,
+	"LogOutput":               true,
+	"LogFilePath":             true,
+	"LogMaxSize":              true,
+	"LogMaxBackups":           true,
+	"LogMaxAge":               true,
+	"LogCompress":             true,
+	"LogRedactPatterns":       true,
+}
+
+// redactedFields 密钥、令牌等敏感配置项，展示时屏蔽
+var redactedFields = map[string]bool{
+	"ZoomAPIKey":             true,
+	"ZoomAPISecret":          true,
+	"ZoomSDKKey":             true,
+	"ZoomSDKSecret":          true,
+	"ZoomVideoSDKKey":        true,
+	"ZoomVideoSDKSecret":     true,
+	"ZoomClientSecret":       true,
+	"ZoomWebhookSecretToken": true,
+	"AdminToken":             true,
+}
+
+// Sanitized 返回可展示的生效配置，只有 exposedFields 中的配置项原样返回，其余均已屏蔽（未配置时为空字符串）
+func (c *Config) Sanitized() map[string]interface{} {
+	result := make(map[string]interface{})
+	value := reflect.ValueOf(c).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		fieldValue := value.Field(i)
+		switch {
+		case exposedFields[field.Name]:
+			result[field.Name] = fieldValue.Interface()
+		case fieldValue.IsZero():
+			result[field.Name] = ""
+		default:
+			result[field.Name] = redactedValue
+		}
+	}
+	return result
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestConfigFieldsClassified 新增配置项时必须决定能否在管理接口中展示
+func TestConfigFieldsClassified(t *testing.T) {
+	fields := make(map[string]bool)
+	configType := reflect.TypeOf(Config{})
+	for i := 0; i < configType.NumField(); i++ {
+		name := configType.Field(i).Name
+		fields[name] = true
+		switch {
+		case exposedFields[name] && redactedFields[name]:
+			t.Errorf("config field %s is both exposed and redacted", name)
+		case !exposedFields[name] && !redactedFields[name]:
+			t.Errorf("config field %s is not classified, add it to exposedFields or redactedFields in sanitize.go", name)
+		}
+	}
+	for _, classified := range []map[string]bool{exposedFields, redactedFields} {
+		for name := range classified {
+			if !fields[name] {
+				t.Errorf("%s is classified in sanitize.go but is not a Config field", name)
+			}
+		}
+	}
+}
+
+func TestSanitized(t *testing.T) {
+	cfg := &Config{
+		Port:             "8080",
+		ZoomClientID:     "client",
+		ZoomClientSecret: "secret",
+		ZoomSDKKey:       "sdk-key",
+		AdminToken:       "",
+		ZoomMaxRetries:   2,
+	}
+	got := cfg.Sanitized()
+
+	want := map[string]interface{}{
+		"Port":             "8080",
+		"ZoomClientID":     "client",
+		"ZoomClientSecret": redactedValue,
+		"ZoomSDKKey":       redactedValue,
+		"AdminToken":       "",
+		"ZoomMaxRetries":   2,
+	}
+	for name, value := range want {
+		if !reflect.DeepEqual(got[name], value) {
+			t.Errorf("Sanitized()[%s] = %v, want %v", name, got[name], value)
+		}
+	}
+	if len(got) != reflect.TypeOf(Config{}).NumField() {
+		t.Errorf("Sanitized() returned %d fields, want %d", len(got), reflect.TypeOf(Config{}).NumField())
+	}
+}
@@ -0,0 +1,396 @@
+package fakezoom
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"zoom-app-server/models"
+
+	"github.com/gorilla/mux"
+)
+
+// meeting 模拟服务中保存的会议
+type meeting struct {
+	models.CreateMeetingResponse
+	Agenda  string
+	Deleted bool
+}
+
+// parseStartTime 解析 Zoom 接受的开始时间格式
+func parseStartTime(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// buildOccurrences 根据重复规则生成单次会议
+func buildOccurrences(start time.Time, duration int, rec *models.Recurrence) []models.Occurrence {
+	if rec == nil {
+		return nil
+	}
+	interval := rec.RepeatInterval
+	if interval <= 0 {
+		interval = 1
+	}
+	count := rec.EndTimes
+	if count <= 0 {
+		count = 7
+	}
+	var endAt time.Time
+	if rec.EndDateTime != "" {
+		endAt, _ = parseStartTime(rec.EndDateTime)
+		count = 60
+	}
+
+	occurrences := make([]models.Occurrence, 0, count)
+	at := start
+	for i := 0; i < count; i++ {
+		if !endAt.IsZero() && at.After(endAt) {
+			break
+		}
+		occurrences = append(occurrences, models.Occurrence{
+			OccurrenceID: strconv.FormatInt(at.UnixMilli(), 10),
+			StartTime:    at,
+			Duration:     duration,
+			Status:       "available",
+		})
+		switch rec.Type {
+		case models.RecurrenceTypeWeekly:
+			at = at.AddDate(0, 0, 7*interval)
+		case models.RecurrenceTypeMonthly:
+			at = at.AddDate(0, interval, 0)
+		default:
+			at = at.AddDate(0, 0, interval)
+		}
+	}
+	return occurrences
+}
+
+// findMeetingLocked 按路径参数查找会议，调用方需持有锁
+func (s *Server) findMeetingLocked(w http.ResponseWriter, r *http.Request, includeDeleted bool) (*meeting, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["meetingId"], 10, 64)
+	if err == nil {
+		if m, ok := s.meetings[id]; ok && (includeDeleted || !m.Deleted) {
+			return m, true
+		}
+	}
+	writeError(w, http.StatusNotFound, 3001, "Meeting does not exist: "+mux.Vars(r)["meetingId"]+".")
+	return nil, false
+}
+
+// handleCreateMeeting 创建会议
+func (s *Server) handleCreateMeeting(w http.ResponseWriter, r *http.Request) {
+	host, ok := s.lookupUser(mux.Vars(r)["userId"])
+	if !ok {
+		writeError(w, http.StatusNotFound, 1001, "User does not exist: "+mux.Vars(r)["userId"]+".")
+		return
+	}
+
+	var req models.CreateMeetingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, 300, "Request Body should be a valid JSON object.")
+		return
+	}
+	if req.Type == 0 {
+		req.Type = models.MeetingTypeScheduled
+	}
+	if req.Duration == 0 {
+		req.Duration = 60
+	}
+
+	start := time.Now().UTC()
+	if req.StartTime != "" {
+		t, ok := parseStartTime(req.StartTime)
+		if !ok {
+			writeError(w, http.StatusBadRequest, 300, "Validation Failed.",
+				models.ZoomFieldError{Field: "start_time", Message: "Invalid field."})
+			return
+		}
+		start = t
+	}
+	if req.Type == models.MeetingTypeRecurringFixedTime && req.Recurrence == nil {
+		writeError(w, http.StatusBadRequest, 300, "Validation Failed.",
+			models.ZoomFieldError{Field: "recurrence", Message: "Recurrence is required for recurring meetings with fixed time."})
+		return
+	}
+
+	s.mu.Lock()
+	s.nextID++
+	m := &meeting{
+		CreateMeetingResponse: models.CreateMeetingResponse{
+			UUID:         randomUUID(),
+			ID:           s.nextID,
+			HostID:       host.ID,
+			HostEmail:    host.Email,
+			Topic:        req.Topic,
+			Type:         req.Type,
+			Status:       "waiting",
+			StartTime:    start,
+			Duration:     req.Duration,
+			Timezone:     req.Timezone,
+			CreatedAt:    time.Now().UTC(),
+			Password:     req.Password,
+			H323Password: req.Password,
+			PSTNPassword: req.Password,
+			Settings:     req.Settings,
+			Recurrence:   req.Recurrence,
+			Occurrences:  buildOccurrences(start, req.Duration, req.Recurrence),
+		},
+		Agenda: req.Agenda,
+	}
+	m.JoinURL = "https://zoom.us/j/" + strconv.FormatInt(m.ID, 10)
+	s.meetings[m.ID] = m
+	resp := m.CreateMeetingResponse
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// handleListMeetings 获取会议列表
+func (s *Server) handleListMeetings(w http.ResponseWriter, r *http.Request) {
+	host, ok := s.lookupUser(mux.Vars(r)["userId"])
+	if !ok {
+		writeError(w, http.StatusNotFound, 1001, "User does not exist: "+mux.Vars(r)["userId"]+".")
+		return
+	}
+
+	query := r.URL.Query()
+	listType := query.Get("type")
+	pageSize, _ := strconv.Atoi(query.Get("page_size"))
+	if pageSize <= 0 || pageSize > 300 {
+		pageSize = 30
+	}
+	offset, _ := strconv.Atoi(query.Get("next_page_token"))
+	if pageNumber, _ := strconv.Atoi(query.Get("page_number")); pageNumber > 1 && offset == 0 {
+		offset = (pageNumber - 1) * pageSize
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	items := []models.MeetingListItem{}
+	for _, m := range s.meetings {
+		if m.Deleted || m.HostID != host.ID {
+			continue
+		}
+		end := m.StartTime.Add(time.Duration(m.Duration) * time.Minute)
+		switch listType {
+		case "live":
+			if m.Status != "started" {
+				continue
+			}
+		case "upcoming", "upcoming_meetings":
+			if end.Before(now) {
+				continue
+			}
+		case "previous_meetings":
+			if !end.Before(now) {
+				continue
+			}
+		}
+		items = append(items, models.MeetingListItem{
+			UUID:      m.UUID,
+			ID:        m.ID,
+			HostID:    m.HostID,
+			Topic:     m.Topic,
+			Type:      m.Type,
+			StartTime: m.StartTime,
+			Duration:  m.Duration,
+			Timezone:  m.Timezone,
+			Agenda:    m.Agenda,
+			CreatedAt: m.CreatedAt,
+			JoinURL:   m.JoinURL,
+		})
+	}
+	s.mu.Unlock()
+	sort.Slice(items, func(i, j int) bool { return items[i].StartTime.Before(items[j].StartTime) })
+
+	total := len(items)
+	if offset > total {
+		offset = total
+	}
+	end := offset + pageSize
+	nextPageToken := ""
+	if end < total {
+		nextPageToken = strconv.Itoa(end)
+	} else {
+		end = total
+	}
+
+	writeJSON(w, http.StatusOK, models.ListMeetingsResponse{
+		PageSize:      pageSize,
+		TotalRecords:  total,
+		NextPageToken: nextPageToken,
+		Meetings:      items[offset:end],
+	})
+}
+
+// handleGetMeeting 获取会议详情
+func (s *Server) handleGetMeeting(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.findMeetingLocked(w, r, false)
+	if !ok {
+		return
+	}
+
+	resp := m.CreateMeetingResponse
+	showPrevious := r.URL.Query().Get("show_previous_occurrences") == "true"
+	resp.Occurrences = nil
+	for _, o := range m.Occurrences {
+		if o.Status == "deleted" {
+			continue
+		}
+		if !showPrevious && o.StartTime.Add(time.Duration(o.Duration)*time.Minute).Before(time.Now()) {
+			continue
+		}
+		resp.Occurrences = append(resp.Occurrences, o)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleUpdateMeeting 更新会议或单次会议
+func (s *Server) handleUpdateMeeting(w http.ResponseWriter, r *http.Request) {
+	var req models.UpdateMeetingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, 300, "Request Body should be a valid JSON object.")
+		return
+	}
+	var start time.Time
+	if req.StartTime != nil {
+		t, ok := parseStartTime(*req.StartTime)
+		if !ok {
+			writeError(w, http.StatusBadRequest, 300, "Validation Failed.",
+				models.ZoomFieldError{Field: "start_time", Message: "Invalid field."})
+			return
+		}
+		start = t
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.findMeetingLocked(w, r, false)
+	if !ok {
+		return
+	}
+
+	if occurrenceID := r.URL.Query().Get("occurrence_id"); occurrenceID != "" {
+		for i := range m.Occurrences {
+			if m.Occurrences[i].OccurrenceID != occurrenceID {
+				continue
+			}
+			if !start.IsZero() {
+				m.Occurrences[i].StartTime = start
+			}
+			if req.Duration != nil {
+				m.Occurrences[i].Duration = *req.Duration
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeError(w, http.StatusNotFound, 3001, "Invalid occurrence_id: "+occurrenceID+".")
+		return
+	}
+
+	if req.Topic != nil {
+		m.Topic = *req.Topic
+	}
+	if req.Type != nil {
+		m.Type = *req.Type
+	}
+	if !start.IsZero() {
+		m.StartTime = start
+	}
+	if req.Duration != nil {
+		m.Duration = *req.Duration
+	}
+	if req.Timezone != nil {
+		m.Timezone = *req.Timezone
+	}
+	if req.Password != nil {
+		m.Password = *req.Password
+	}
+	if req.Agenda != nil {
+		m.Agenda = *req.Agenda
+	}
+	if req.Settings != nil {
+		// 与Zoom一致，只合并提交的设置项；复制一份以免影响已发出的事件快照
+		var settings models.MeetingSettings
+		if m.Settings != nil {
+			settings = *m.Settings
+		}
+		req.Settings.ApplyTo(&settings)
+		m.Settings = &settings
+	}
+	if req.Recurrence != nil {
+		m.Recurrence = req.Recurrence
+		m.Occurrences = buildOccurrences(m.StartTime, m.Duration, m.Recurrence)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleDeleteMeeting 删除会议或单次会议
+func (s *Server) handleDeleteMeeting(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.findMeetingLocked(w, r, false)
+	if !ok {
+		return
+	}
+
+	if occurrenceID := r.URL.Query().Get("occurrence_id"); occurrenceID != "" {
+		for i := range m.Occurrences {
+			if m.Occurrences[i].OccurrenceID == occurrenceID {
+				m.Occurrences[i].Status = "deleted"
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, 3001, "Invalid occurrence_id: "+occurrenceID+".")
+		return
+	}
+
+	m.Deleted = true
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleUpdateMeetingStatus 结束或恢复会议
+func (s *Server) handleUpdateMeetingStatus(w http.ResponseWriter, r *http.Request) {
+	var req models.UpdateMeetingStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, 300, "Request Body should be a valid JSON object.")
+		return
+	}
+
+	s.mu.Lock()
+	m, ok := s.findMeetingLocked(w, r, req.Action == "recover")
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	wasStarted := false
+	switch req.Action {
+	case "end":
+		wasStarted = m.Status == "started"
+		m.Status = "waiting"
+	case "recover":
+		m.Deleted = false
+	default:
+		s.mu.Unlock()
+		writeError(w, http.StatusBadRequest, 300, "Validation Failed.",
+			models.ZoomFieldError{Field: "action", Message: "Invalid field."})
+		return
+	}
+	snapshot := m.CreateMeetingResponse
+	s.mu.Unlock()
+
+	if wasStarted {
+		go s.sendMeetingEvent(models.WebhookEventMeetingEnded, &snapshot, nil)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,255 @@
+// Package fakezoom 提供内存中的 Zoom API 模拟服务，用于本地开发和测试
+// 覆盖 Server-To-Server OAuth、会议增删改查、用户令牌以及 Webhook 推送
+package fakezoom
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"zoom-app-server/models"
+
+	"github.com/gorilla/mux"
+)
+
+// tokenTTL 模拟服务签发的访问令牌有效期
+const tokenTTL = time.Hour
+
+// Config 模拟服务配置
+type Config struct {
+	AccountID     string // 期望的 account_id，为空时不校验
+	ClientID      string // 期望的 client_id，为空时不校验
+	ClientSecret  string // 期望的 client_secret
+	HostEmail     string // 默认主持人邮箱
+	WebhookURL    string // Webhook 推送地址，为空时不推送
+	WebhookSecret string // Webhook 签名密钥
+}
+
+// User 模拟的 Zoom 用户
+type User struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Type      int    `json:"type"`
+	Status    string `json:"status"`
+}
+
+// Server Zoom API 模拟服务
+type Server struct {
+	cfg    Config
+	router *mux.Router
+	client *http.Client
+
+	mu       sync.Mutex
+	tokens   map[string]time.Time
+	users    map[string]*User
+	meetings map[int64]*meeting
+	nextID   int64
+}
+
+// New 创建模拟服务
+func New(cfg Config) *Server {
+	if cfg.HostEmail == "" {
+		cfg.HostEmail = "host@example.com"
+	}
+
+	s := &Server{
+		cfg:      cfg,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		tokens:   make(map[string]time.Time),
+		users:    make(map[string]*User),
+		meetings: make(map[int64]*meeting),
+		nextID:   85000000000,
+	}
+	s.AddUser(&User{
+		ID:        "fakezoomhost",
+		Email:     cfg.HostEmail,
+		FirstName: "Fake",
+		LastName:  "Host",
+		Type:      2,
+		Status:    "active",
+	})
+
+	r := mux.NewRouter()
+	r.HandleFunc("/oauth/token", s.handleOAuthToken).Methods("POST")
+
+	api := r.PathPrefix("/v2").Subrouter()
+	api.Use(s.requireToken)
+	api.HandleFunc("/users/{userId}", s.handleGetUser).Methods("GET")
+	api.HandleFunc("/users/{userId}/token", s.handleUserToken).Methods("GET")
+	api.HandleFunc("/users/{userId}/meetings", s.handleCreateMeeting).Methods("POST")
+	api.HandleFunc("/users/{userId}/meetings", s.handleListMeetings).Methods("GET")
+	api.HandleFunc("/meetings/{meetingId}", s.handleGetMeeting).Methods("GET")
+	api.HandleFunc("/meetings/{meetingId}", s.handleUpdateMeeting).Methods("PATCH")
+	api.HandleFunc("/meetings/{meetingId}", s.handleDeleteMeeting).Methods("DELETE")
+	api.HandleFunc("/meetings/{meetingId}/status", s.handleUpdateMeetingStatus).Methods("PUT")
+
+	// 模拟服务专用接口：手动触发 Webhook 事件
+	r.HandleFunc("/fake/meetings/{meetingId}/events/{event}", s.handleTriggerEvent).Methods("POST")
+
+	s.router = r
+	return s
+}
+
+// NewTestServer 创建并启动用于 httptest 的模拟服务
+func NewTestServer(cfg Config) (*Server, *httptest.Server) {
+	s := New(cfg)
+	return s, httptest.NewServer(s)
+}
+
+// ServeHTTP 实现 http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.router.ServeHTTP(w, r)
+}
+
+// AddUser 添加模拟用户
+func (s *Server) AddUser(user *User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[user.ID] = user
+	s.users[strings.ToLower(user.Email)] = user
+}
+
+// IssueToken 直接签发访问令牌，便于测试跳过 OAuth 流程
+func (s *Server) IssueToken() string {
+	token := randomString(24)
+	s.mu.Lock()
+	s.tokens[token] = time.Now().Add(tokenTTL)
+	s.mu.Unlock()
+	return token
+}
+
+// RevokeTokens 使所有已签发的令牌失效，用于模拟令牌过期
+func (s *Server) RevokeTokens() {
+	s.mu.Lock()
+	s.tokens = make(map[string]time.Time)
+	s.mu.Unlock()
+}
+
+// handleOAuthToken 模拟 Server-To-Server OAuth 令牌接口
+func (s *Server) handleOAuthToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "account_credentials" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"reason": "Unsupported grant type", "error": "unsupported_grant_type"})
+		return
+	}
+	if s.cfg.AccountID != "" && r.PostForm.Get("account_id") != s.cfg.AccountID {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"reason": "Invalid account_id", "error": "invalid_request"})
+		return
+	}
+	if s.cfg.ClientID != "" {
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != s.cfg.ClientID || clientSecret != s.cfg.ClientSecret {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"reason": "Invalid client_id or client_secret", "error": "invalid_client"})
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, models.OAuthTokenResponse{
+		AccessToken: s.IssueToken(),
+		TokenType:   "bearer",
+		ExpiresIn:   int(tokenTTL.Seconds()),
+		Scope:       "meeting:read:admin meeting:write:admin user:read:admin user:read:token:admin",
+	})
+}
+
+// requireToken 校验 Bearer 令牌
+func (s *Server) requireToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		s.mu.Lock()
+		expiresAt, ok := s.tokens[token]
+		s.mu.Unlock()
+		if !ok || time.Now().After(expiresAt) {
+			writeError(w, http.StatusUnauthorized, 124, "Invalid access token.")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// lookupUser 按用户ID、邮箱或 me 查找用户
+func (s *Server) lookupUser(userID string) (*User, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if userID == "me" {
+		userID = strings.ToLower(s.cfg.HostEmail)
+	}
+	user, ok := s.users[strings.ToLower(userID)]
+	if !ok {
+		user, ok = s.users[userID]
+	}
+	return user, ok
+}
+
+// handleGetUser 获取用户
+func (s *Server) handleGetUser(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.lookupUser(mux.Vars(r)["userId"])
+	if !ok {
+		writeError(w, http.StatusNotFound, 1001, "User does not exist: "+mux.Vars(r)["userId"]+".")
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+// handleUserToken 获取用户的 ZAK/OBF 令牌
+func (s *Server) handleUserToken(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.lookupUser(mux.Vars(r)["userId"])
+	if !ok {
+		writeError(w, http.StatusNotFound, 1001, "User does not exist: "+mux.Vars(r)["userId"]+".")
+		return
+	}
+
+	tokenType := r.URL.Query().Get("type")
+	switch tokenType {
+	case models.UserTokenTypeZAK, "token":
+	case models.UserTokenTypeOBF:
+		if r.URL.Query().Get("meeting_id") == "" {
+			writeError(w, http.StatusBadRequest, 300, "meeting_id is required for onbehalf token.")
+			return
+		}
+	default:
+		writeError(w, http.StatusBadRequest, 300, "Invalid token type.")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.ZoomUserTokenResponse{
+		Token: "fake-" + tokenType + "-" + user.ID + "-" + randomString(8),
+	})
+}
+
+// writeJSON 写入 JSON 响应
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError 写入 Zoom 格式的错误响应
+func writeError(w http.ResponseWriter, status, code int, message string, fieldErrors ...models.ZoomFieldError) {
+	writeJSON(w, status, models.ZoomErrorResponse{
+		Code:    code,
+		Message: message,
+		Errors:  fieldErrors,
+	})
+}
+
+// randomString 生成随机字符串
+func randomString(n int) string {
+	buf := make([]byte, n)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// randomUUID 生成 Zoom 风格的会议 UUID
+func randomUUID() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return base64.StdEncoding.EncodeToString(buf)
+}
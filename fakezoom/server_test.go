@@ -0,0 +1,295 @@
+package fakezoom
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"zoom-app-server/models"
+)
+
+// testClient 携带访问令牌请求模拟服务
+type testClient struct {
+	t       *testing.T
+	baseURL string
+	token   string
+}
+
+// newTestClient 启动模拟服务并直接签发令牌
+func newTestClient(t *testing.T, cfg Config) (*Server, *testClient) {
+	t.Helper()
+	s, server := NewTestServer(cfg)
+	t.Cleanup(server.Close)
+	return s, &testClient{t: t, baseURL: server.URL, token: s.IssueToken()}
+}
+
+// do 发送请求，out 不为nil时解析响应
+func (c *testClient) do(method, path string, body, out interface{}) int {
+	c.t.Helper()
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			c.t.Fatalf("marshal body: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
+	if err != nil {
+		c.t.Fatalf("new request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			c.t.Fatalf("decode %s %s: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+// createMeeting 创建会议并返回响应
+func (c *testClient) createMeeting(req models.CreateMeetingRequest) models.CreateMeetingResponse {
+	c.t.Helper()
+	var meeting models.CreateMeetingResponse
+	if status := c.do("POST", "/v2/users/me/meetings", req, &meeting); status != http.StatusCreated {
+		c.t.Fatalf("create meeting status = %d, want 201", status)
+	}
+	return meeting
+}
+
+func TestOAuthToken(t *testing.T) {
+	s, server := NewTestServer(Config{AccountID: "account", ClientID: "client", ClientSecret: "secret"})
+	defer server.Close()
+
+	requestToken := func(accountID, clientSecret string) (int, models.OAuthTokenResponse) {
+		form := url.Values{"grant_type": {"account_credentials"}, "account_id": {accountID}}
+		req, _ := http.NewRequest("POST", server.URL+"/oauth/token", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth("client", clientSecret)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request token: %v", err)
+		}
+		defer resp.Body.Close()
+		var token models.OAuthTokenResponse
+		json.NewDecoder(resp.Body).Decode(&token)
+		return resp.StatusCode, token
+	}
+
+	if status, _ := requestToken("account", "wrong"); status != http.StatusUnauthorized {
+		t.Errorf("wrong secret status = %d, want 401", status)
+	}
+	if status, _ := requestToken("other", "secret"); status != http.StatusBadRequest {
+		t.Errorf("wrong account status = %d, want 400", status)
+	}
+	status, token := requestToken("account", "secret")
+	if status != http.StatusOK || token.AccessToken == "" || token.ExpiresIn != int(tokenTTL.Seconds()) {
+		t.Fatalf("token = %d %+v, want 200 with access token", status, token)
+	}
+
+	// 签发的令牌可以调用API，撤销后失效
+	c := &testClient{t: t, baseURL: server.URL, token: token.AccessToken}
+	if status := c.do("GET", "/v2/users/me", nil, nil); status != http.StatusOK {
+		t.Errorf("get user with token status = %d, want 200", status)
+	}
+	s.RevokeTokens()
+	if status := c.do("GET", "/v2/users/me", nil, nil); status != http.StatusUnauthorized {
+		t.Errorf("get user after revoke status = %d, want 401", status)
+	}
+}
+
+func TestMeetingCRUD(t *testing.T) {
+	_, c := newTestClient(t, Config{HostEmail: "host@example.com"})
+
+	created := c.createMeeting(models.CreateMeetingRequest{
+		Topic:     "周会",
+		StartTime: "2030-01-02T03:04:05Z",
+		Duration:  30,
+		Settings:  &models.MeetingSettings{HostVideo: true, WaitingRoom: true},
+	})
+	if created.ID != 85000000001 || created.Type != models.MeetingTypeScheduled || created.HostEmail != "host@example.com" {
+		t.Errorf("created = %+v", created)
+	}
+	path := fmt.Sprintf("/v2/meetings/%d", created.ID)
+
+	var got models.CreateMeetingResponse
+	if status := c.do("GET", path, nil, &got); status != http.StatusOK || got.Topic != "周会" || got.Duration != 30 {
+		t.Fatalf("get = %d %+v", status, got)
+	}
+
+	// 只合并提交的设置项
+	topic, waitingRoom := "周会（改期）", false
+	update := models.UpdateMeetingRequest{
+		Topic:    &topic,
+		Settings: &models.MeetingSettingsUpdate{WaitingRoom: &waitingRoom},
+	}
+	if status := c.do("PATCH", path, update, nil); status != http.StatusNoContent {
+		t.Fatalf("patch status = %d, want 204", status)
+	}
+	got = models.CreateMeetingResponse{}
+	c.do("GET", path, nil, &got)
+	if got.Topic != topic || got.Settings == nil || !got.Settings.HostVideo || got.Settings.WaitingRoom {
+		t.Errorf("after patch = %+v settings %+v", got, got.Settings)
+	}
+
+	var list models.ListMeetingsResponse
+	if status := c.do("GET", "/v2/users/me/meetings", nil, &list); status != http.StatusOK || list.TotalRecords != 1 {
+		t.Errorf("list = %d %+v, want 1 meeting", status, list)
+	}
+
+	if status := c.do("DELETE", path, nil, nil); status != http.StatusNoContent {
+		t.Fatalf("delete status = %d, want 204", status)
+	}
+	var zoomErr models.ZoomErrorResponse
+	if status := c.do("GET", path, nil, &zoomErr); status != http.StatusNotFound || zoomErr.Code != 3001 {
+		t.Errorf("get deleted = %d %+v, want 404 code 3001", status, zoomErr)
+	}
+
+	// 已删除的会议可以恢复
+	if status := c.do("PUT", path+"/status", models.UpdateMeetingStatusRequest{Action: "recover"}, nil); status != http.StatusNoContent {
+		t.Errorf("recover status = %d, want 204", status)
+	}
+	if status := c.do("GET", path, nil, nil); status != http.StatusOK {
+		t.Errorf("get recovered status = %d, want 200", status)
+	}
+
+	zoomErr = models.ZoomErrorResponse{}
+	status := c.do("POST", "/v2/users/me/meetings", models.CreateMeetingRequest{Topic: "x", StartTime: "tomorrow"}, &zoomErr)
+	if status != http.StatusBadRequest || zoomErr.Code != 300 || len(zoomErr.Errors) != 1 || zoomErr.Errors[0].Field != "start_time" {
+		t.Errorf("invalid start_time = %d %+v, want 400 code 300 on start_time", status, zoomErr)
+	}
+}
+
+func TestMeetingOccurrences(t *testing.T) {
+	_, c := newTestClient(t, Config{})
+
+	start := time.Now().Add(-36 * time.Hour).UTC().Truncate(time.Second)
+	created := c.createMeeting(models.CreateMeetingRequest{
+		Topic:      "站会",
+		Type:       models.MeetingTypeRecurringFixedTime,
+		StartTime:  start.Format(time.RFC3339),
+		Duration:   15,
+		Recurrence: &models.Recurrence{Type: models.RecurrenceTypeDaily, EndTimes: 5},
+	})
+	if len(created.Occurrences) != 5 || !created.Occurrences[1].StartTime.Equal(start.AddDate(0, 0, 1)) {
+		t.Fatalf("occurrences = %+v, want 5 daily", created.Occurrences)
+	}
+	path := fmt.Sprintf("/v2/meetings/%d", created.ID)
+
+	// 默认不返回已结束的单次会议
+	var got models.CreateMeetingResponse
+	c.do("GET", path, nil, &got)
+	if len(got.Occurrences) != 3 {
+		t.Errorf("upcoming occurrences = %d, want 3", len(got.Occurrences))
+	}
+	got = models.CreateMeetingResponse{}
+	c.do("GET", path+"?show_previous_occurrences=true", nil, &got)
+	if len(got.Occurrences) != 5 {
+		t.Errorf("all occurrences = %d, want 5", len(got.Occurrences))
+	}
+
+	// 修改和删除单次会议
+	last := created.Occurrences[4]
+	duration := 45
+	if status := c.do("PATCH", path+"?occurrence_id="+last.OccurrenceID, models.UpdateMeetingRequest{Duration: &duration}, nil); status != http.StatusNoContent {
+		t.Errorf("patch occurrence status = %d, want 204", status)
+	}
+	if status := c.do("DELETE", path+"?occurrence_id="+created.Occurrences[3].OccurrenceID, nil, nil); status != http.StatusNoContent {
+		t.Errorf("delete occurrence status = %d, want 204", status)
+	}
+	got = models.CreateMeetingResponse{}
+	c.do("GET", path, nil, &got)
+	if len(got.Occurrences) != 2 || got.Occurrences[1].OccurrenceID != last.OccurrenceID || got.Occurrences[1].Duration != 45 {
+		t.Errorf("occurrences after changes = %+v", got.Occurrences)
+	}
+	if status := c.do("DELETE", path+"?occurrence_id=1", nil, nil); status != http.StatusNotFound {
+		t.Errorf("delete unknown occurrence status = %d, want 404", status)
+	}
+
+	// 固定时间的定期会议必须提供重复规则
+	status := c.do("POST", "/v2/users/me/meetings", models.CreateMeetingRequest{Type: models.MeetingTypeRecurringFixedTime}, nil)
+	if status != http.StatusBadRequest {
+		t.Errorf("recurring without recurrence status = %d, want 400", status)
+	}
+}
+
+func TestTriggerWebhookEvent(t *testing.T) {
+	const secret = "webhook-secret"
+	events := make(chan models.WebhookEvent, 4)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte("v0:" + r.Header.Get("x-zm-request-timestamp") + ":" + string(body)))
+		if r.Header.Get("x-zm-signature") != "v0="+hex.EncodeToString(mac.Sum(nil)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var event models.WebhookEvent
+		json.Unmarshal(body, &event)
+		events <- event
+	}))
+	defer receiver.Close()
+
+	_, c := newTestClient(t, Config{AccountID: "account", WebhookURL: receiver.URL, WebhookSecret: secret})
+	created := c.createMeeting(models.CreateMeetingRequest{Topic: "周会"})
+	trigger := func(event string) int {
+		return c.do("POST", fmt.Sprintf("/fake/meetings/%d/events/%s", created.ID, event), nil, nil)
+	}
+
+	if status := trigger("started"); status != http.StatusNoContent {
+		t.Fatalf("trigger started status = %d, want 204", status)
+	}
+	event := <-events
+	var payload models.WebhookMeetingPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if event.Event != models.WebhookEventMeetingStarted || payload.AccountID != "account" || payload.Object.ID.String() != fmt.Sprint(created.ID) {
+		t.Errorf("event = %s %+v", event.Event, payload)
+	}
+
+	// 结束进行中的会议时异步推送 meeting.ended
+	if status := c.do("PUT", fmt.Sprintf("/v2/meetings/%d/status", created.ID), models.UpdateMeetingStatusRequest{Action: "end"}, nil); status != http.StatusNoContent {
+		t.Fatalf("end meeting status = %d, want 204", status)
+	}
+	select {
+	case event := <-events:
+		if event.Event != models.WebhookEventMeetingEnded {
+			t.Errorf("event after end = %s, want %s", event.Event, models.WebhookEventMeetingEnded)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("meeting.ended not delivered")
+	}
+
+	if status := trigger("unknown"); status != http.StatusBadRequest {
+		t.Errorf("trigger unsupported event status = %d, want 400", status)
+	}
+}
+
+func TestTriggerWebhookEventReceiverFailure(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	_, c := newTestClient(t, Config{WebhookURL: receiver.URL, WebhookSecret: "secret"})
+	created := c.createMeeting(models.CreateMeetingRequest{Topic: "周会"})
+	if status := c.do("POST", fmt.Sprintf("/fake/meetings/%d/events/started", created.ID), nil, nil); status != http.StatusBadGateway {
+		t.Errorf("trigger with failing receiver status = %d, want 502", status)
+	}
+}
@@ -0,0 +1,124 @@
+package fakezoom
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"zoom-app-server/models"
+
+	"github.com/gorilla/mux"
+)
+
+// SendEvent 按 Zoom 的签名规则向 WebhookURL 推送事件
+func (s *Server) SendEvent(event string, payload interface{}) error {
+	if s.cfg.WebhookURL == "" {
+		return nil
+	}
+
+	rawPayload, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	body, err := json.Marshal(models.WebhookEvent{
+		Event:   event,
+		EventTS: now.UnixMilli(),
+		Payload: rawPayload,
+	})
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(s.cfg.WebhookSecret))
+	mac.Write([]byte("v0:" + timestamp + ":" + string(body)))
+
+	req, err := http.NewRequest("POST", s.cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-zm-request-timestamp", timestamp)
+	req.Header.Set("x-zm-signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s returned status %d", event, resp.StatusCode)
+	}
+	return nil
+}
+
+// sendMeetingEvent 推送会议相关事件
+func (s *Server) sendMeetingEvent(event string, m *models.CreateMeetingResponse, participant *models.WebhookParticipant) error {
+	object := models.WebhookMeetingObject{
+		ID:          json.Number(strconv.FormatInt(m.ID, 10)),
+		UUID:        m.UUID,
+		HostID:      m.HostID,
+		Topic:       m.Topic,
+		Type:        m.Type,
+		StartTime:   m.StartTime.Format(time.RFC3339),
+		Duration:    m.Duration,
+		Timezone:    m.Timezone,
+		Participant: participant,
+	}
+	if event == models.WebhookEventMeetingEnded {
+		object.EndTime = time.Now().UTC().Format(time.RFC3339)
+	}
+	return s.SendEvent(event, models.WebhookMeetingPayload{
+		AccountID: s.cfg.AccountID,
+		Object:    object,
+	})
+}
+
+// handleTriggerEvent 手动触发会议事件：started、ended、participant_joined、participant_left
+func (s *Server) handleTriggerEvent(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	m, ok := s.findMeetingLocked(w, r, false)
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+
+	var participant *models.WebhookParticipant
+	event := "meeting." + mux.Vars(r)["event"]
+	switch event {
+	case models.WebhookEventMeetingStarted:
+		m.Status = "started"
+	case models.WebhookEventMeetingEnded:
+		m.Status = "waiting"
+	case models.WebhookEventParticipantJoined, models.WebhookEventParticipantLeft:
+		participant = &models.WebhookParticipant{
+			UserID:   randomString(4),
+			UserName: r.URL.Query().Get("user_name"),
+			Email:    r.URL.Query().Get("email"),
+		}
+		if event == models.WebhookEventParticipantJoined {
+			participant.JoinTime = time.Now().UTC().Format(time.RFC3339)
+		} else {
+			participant.LeaveTime = time.Now().UTC().Format(time.RFC3339)
+		}
+	default:
+		s.mu.Unlock()
+		writeError(w, http.StatusBadRequest, 300, "Unsupported event: "+mux.Vars(r)["event"]+".")
+		return
+	}
+	snapshot := m.CreateMeetingResponse
+	s.mu.Unlock()
+
+	if err := s.sendMeetingEvent(event, &snapshot, participant); err != nil {
+		writeError(w, http.StatusBadGateway, 500, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+	"strings"
+	"sync"
+	"time"
+
+	"zoom-app-server/config"
+	"zoom-app-server/models"
+	"zoom-app-server/utils/logger"
+	"zoom-app-server/utils/response"
+	"zoom-app-server/version"
+
+	"github.com/sirupsen/logrus"
+)
+
+// AdminPathPrefix 管理接口路径前缀
+const AdminPathPrefix = "/api/admin"
+
+// maxAdminDuration 临时调整日志级别、开启pprof的最长时间
+const maxAdminDuration = 24 * time.Hour
+
+// AdminHandler 管理与诊断接口处理器
+type AdminHandler struct {
+	cfg   *config.Config
+	pprof http.Handler
+
+	pprofMu        sync.Mutex
+	pprofEnabled   bool
+	pprofDisableAt time.Time
+	pprofTimer     *time.Timer
+}
+
+// NewAdminHandler 创建管理接口处理器
+func NewAdminHandler(cfg *config.Config) *AdminHandler {
+	// pprof.Index 按 /debug/pprof/ 前缀解析profile名称，挂载时需去掉管理接口前缀
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	return &AdminHandler{
+		cfg:   cfg,
+		pprof: http.StripPrefix(AdminPathPrefix, mux),
+	}
+}
+
+// HandleGetLogLevel 获取当前日志级别
+func (h *AdminHandler) HandleGetLogLevel(w http.ResponseWriter, r *http.Request) {
+	response.WriteSuccess(w, newLogLevelResponse(logger.GetLevelStatus()), "获取日志级别成功")
+}
+
+// HandleSetLogLevel 运行时修改日志级别，可指定到期自动恢复
+func (h *AdminHandler) HandleSetLogLevel(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	var req models.SetLogLevelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.WithError(err).Error("Failed to decode set log level request")
+		response.WriteBadRequest(w, "请求参数格式错误")
+		return
+	}
+	level, err := logrus.ParseLevel(strings.TrimSpace(req.Level))
+	if err != nil || level < logrus.ErrorLevel {
+		response.WriteBadRequest(w, "日志级别无效，可选值：trace、debug、info、warn、error")
+		return
+	}
+	duration := time.Duration(req.DurationSeconds) * time.Second
+	if duration < 0 || duration > maxAdminDuration {
+		response.WriteBadRequest(w, "duration_seconds 必须在0-86400之间")
+		return
+	}
+
+	previous := logger.GetLevelStatus().Level
+	status := logger.SetLevel(level, duration)
+	log.WithFields(logrus.Fields{
+		"previous_log_level": previous.String(),
+		"log_level":          level.String(),
+		"duration_seconds":   req.DurationSeconds,
+	}).Warn("Log level changed at runtime")
+
+	response.WriteSuccess(w, newLogLevelResponse(status), "日志级别已修改")
+}
+
+// HandleGetConfig 获取屏蔽敏感项后的生效配置
+func (h *AdminHandler) HandleGetConfig(w http.ResponseWriter, r *http.Request) {
+	response.WriteSuccess(w, h.cfg.Sanitized(), "获取配置成功")
+}
+
+// HandleGetVersion 获取构建版本信息
+func (h *AdminHandler) HandleGetVersion(w http.ResponseWriter, r *http.Request) {
+	response.WriteSuccess(w, version.Get(), "获取版本信息成功")
+}
+
+// HandleGetPprof 获取pprof开启状态
+func (h *AdminHandler) HandleGetPprof(w http.ResponseWriter, r *http.Request) {
+	h.pprofMu.Lock()
+	defer h.pprofMu.Unlock()
+	response.WriteSuccess(w, h.pprofStatus(), "获取pprof状态成功")
+}
+
+// HandleSetPprof 开启或关闭pprof，开启时可指定到期自动关闭
+func (h *AdminHandler) HandleSetPprof(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	var req models.SetPprofRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.WithError(err).Error("Failed to decode set pprof request")
+		response.WriteBadRequest(w, "请求参数格式错误")
+		return
+	}
+	duration := time.Duration(req.DurationSeconds) * time.Second
+	if duration < 0 || duration > maxAdminDuration {
+		response.WriteBadRequest(w, "duration_seconds 必须在0-86400之间")
+		return
+	}
+
+	h.pprofMu.Lock()
+	defer h.pprofMu.Unlock()
+
+	if h.pprofTimer != nil {
+		h.pprofTimer.Stop()
+		h.pprofTimer = nil
+	}
+	h.pprofEnabled = req.Enabled
+	h.pprofDisableAt = time.Time{}
+	if req.Enabled && duration > 0 {
+		h.pprofDisableAt = time.Now().Add(duration)
+		var timer *time.Timer
+		timer = time.AfterFunc(duration, func() {
+			h.pprofMu.Lock()
+			defer h.pprofMu.Unlock()
+			if h.pprofTimer != timer {
+				return
+			}
+			h.pprofTimer = nil
+			h.pprofEnabled = false
+			h.pprofDisableAt = time.Time{}
+			logger.Info("pprof disabled after timeout")
+		})
+		h.pprofTimer = timer
+	}
+
+	log.WithFields(logrus.Fields{
+		"enabled":          req.Enabled,
+		"duration_seconds": req.DurationSeconds,
+	}).Warn("pprof toggled at runtime")
+
+	response.WriteSuccess(w, h.pprofStatus(), "pprof状态已修改")
+}
+
+// HandlePprof 代理到 net/http/pprof，未开启时返回404
+func (h *AdminHandler) HandlePprof(w http.ResponseWriter, r *http.Request) {
+	h.pprofMu.Lock()
+	enabled := h.pprofEnabled
+	h.pprofMu.Unlock()
+
+	if !enabled {
+		response.WriteNotFound(w, "pprof未开启")
+		return
+	}
+	h.pprof.ServeHTTP(w, r)
+}
+
+// pprofStatus 调用方需持有 pprofMu
+func (h *AdminHandler) pprofStatus() models.PprofResponse {
+	status := models.PprofResponse{
+		Enabled:     h.pprofEnabled,
+		ProfilePath: AdminPathPrefix + "/debug/pprof/",
+	}
+	if !h.pprofDisableAt.IsZero() {
+		disableAt := h.pprofDisableAt
+		status.DisableAt = &disableAt
+	}
+	return status
+}
+
+// newLogLevelResponse 将日志级别状态转换为响应结构
+func newLogLevelResponse(status logger.LevelStatus) models.LogLevelResponse {
+	resp := models.LogLevelResponse{Level: status.Level.String()}
+	if !status.RevertAt.IsZero() {
+		revertAt := status.RevertAt
+		resp.RevertLevel = status.RevertLevel.String()
+		resp.RevertAt = &revertAt
+	}
+	return resp
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"zoom-app-server/models"
+	"zoom-app-server/services"
+	"zoom-app-server/utils/response"
+)
+
+// writeZoomError 将Zoom调用错误转换为对应的HTTP状态码和业务错误码
+// 无法识别的错误使用fallback消息返回500
+func writeZoomError(w http.ResponseWriter, err error, fallback string) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		response.WriteError(w, http.StatusGatewayTimeout, response.CodeZoomTimeout, fallback+"：Zoom响应超时")
+		return
+	}
+	if errors.Is(err, services.ErrZoomRateLimited) {
+		response.WriteTooManyRequests(w, response.CodeZoomRateLimited, "Zoom请求过于频繁，请稍后重试")
+		return
+	}
+
+	var apiErr *services.ZoomAPIError
+	if !errors.As(err, &apiErr) {
+		response.WriteInternalError(w, fallback)
+		return
+	}
+
+	detail := models.ZoomErrorDetail{
+		ZoomCode:    apiErr.Code,
+		ZoomMessage: apiErr.Message,
+		Errors:      apiErr.Errors,
+	}
+	switch {
+	case apiErr.Action == services.ActionGetOAuthToken && apiErr.StatusCode != http.StatusTooManyRequests && apiErr.StatusCode < 500:
+		response.WriteError(w, http.StatusBadGateway, response.CodeZoomAuthFailed, "Zoom认证失败", detail)
+	case apiErr.Code == services.ZoomCodeInvalidField:
+		response.WriteError(w, http.StatusBadRequest, response.CodeZoomInvalidField, fallback+"：请求参数无效", detail)
+	case apiErr.Code == services.ZoomCodeUserNotFound:
+		response.WriteError(w, http.StatusNotFound, response.CodeZoomUserNotFound, fallback+"：Zoom用户不存在", detail)
+	case apiErr.Code == services.ZoomCodeMeetingNotFound:
+		response.WriteError(w, http.StatusNotFound, response.CodeZoomMeetingNotFound, fallback+"：会议不存在", detail)
+	case apiErr.Code == services.ZoomCodeRateLimited || apiErr.StatusCode == http.StatusTooManyRequests:
+		response.WriteTooManyRequests(w, response.CodeZoomRateLimited, "Zoom请求过于频繁，请稍后重试", detail)
+	case apiErr.Code == services.ZoomCodeInvalidToken || apiErr.StatusCode == http.StatusUnauthorized:
+		response.WriteError(w, http.StatusBadGateway, response.CodeZoomAuthFailed, "Zoom认证失败", detail)
+	case apiErr.StatusCode == http.StatusNotFound:
+		response.WriteError(w, http.StatusNotFound, response.CodeZoomMeetingNotFound, fallback+"：资源不存在", detail)
+	case apiErr.StatusCode == http.StatusForbidden:
+		response.WriteError(w, http.StatusForbidden, response.CodeZoomForbidden, fallback+"：Zoom账号无权限", detail)
+	case apiErr.StatusCode >= 400 && apiErr.StatusCode < 500:
+		response.WriteError(w, http.StatusBadRequest, response.CodeZoomRequestFailed, fallback, detail)
+	default:
+		response.WriteError(w, http.StatusBadGateway, response.CodeZoomUnavailable, fallback+"：Zoom服务异常", detail)
+	}
+}
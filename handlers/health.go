@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"zoom-app-server/config"
+	"zoom-app-server/models"
+	"zoom-app-server/services"
+	"zoom-app-server/store"
+	"zoom-app-server/utils/logger"
+	"zoom-app-server/utils/response"
+
+	"github.com/sirupsen/logrus"
+)
+
+// readinessCheckTimeout 单项就绪检查的最长时间
+const readinessCheckTimeout = 5 * time.Second
+
+// zoomOAuthFailureTTL OAuth令牌获取失败后缓存失败结果的时间，避免每次探测都请求Zoom
+const zoomOAuthFailureTTL = 30 * time.Second
+
+// errSkipCheck 依赖未启用，跳过检查
+var errSkipCheck = errors.New("check skipped")
+
+// DooTaskPinger 检查DooTask是否可达
+type DooTaskPinger interface {
+	Ping(ctx context.Context) error
+}
+
+// HealthHandler 存活与就绪检查处理器
+type HealthHandler struct {
+	cfg          *config.Config
+	zoomService  services.ZoomClient
+	dooTask      DooTaskPinger
+	meetingStore *store.Store
+
+	oauthMu       sync.Mutex
+	oauthErr      error // 最近一次获取OAuth令牌的错误
+	oauthFailedAt time.Time
+}
+
+// NewHealthHandler 创建健康检查处理器实例
+func NewHealthHandler(cfg *config.Config, zoomService services.ZoomClient, dooTask DooTaskPinger, meetingStore *store.Store) *HealthHandler {
+	return &HealthHandler{
+		cfg:          cfg,
+		zoomService:  zoomService,
+		dooTask:      dooTask,
+		meetingStore: meetingStore,
+	}
+}
+
+// HandleHealthz 存活检查，进程能处理请求即返回成功
+func (h *HealthHandler) HandleHealthz(w http.ResponseWriter, r *http.Request) {
+	response.WriteSuccess(w, map[string]string{"status": models.HealthStatusOK}, "alive")
+}
+
+// HandleReadyz 就绪检查，并发检查各项依赖，任一失败返回503
+func (h *HealthHandler) HandleReadyz(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	checks := map[string]func(ctx context.Context) error{
+		"config":     h.checkConfig,
+		"zoom_oauth": h.checkZoomOAuth,
+		"dootask":    h.checkDooTask,
+		"store":      h.meetingStore.CheckWritable,
+	}
+
+	resp := models.ReadinessResponse{
+		Ready:  true,
+		Checks: make(map[string]models.HealthCheckResult, len(checks)),
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check func(ctx context.Context) error) {
+			defer wg.Done()
+			result := runCheck(r.Context(), check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			resp.Checks[name] = result
+			if result.Status == models.HealthStatusFail {
+				resp.Ready = false
+				log.WithFields(logrus.Fields{
+					"check": name,
+					"error": result.Error,
+				}).Warn("Readiness check failed")
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	if !resp.Ready {
+		response.WriteError(w, http.StatusServiceUnavailable, http.StatusServiceUnavailable, "not ready", resp)
+		return
+	}
+	response.WriteSuccess(w, resp, "ready")
+}
+
+// runCheck 执行单项检查并记录耗时
+func runCheck(ctx context.Context, check func(ctx context.Context) error) models.HealthCheckResult {
+	ctx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	result := models.HealthCheckResult{
+		Status:    models.HealthStatusOK,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	switch {
+	case errors.Is(err, errSkipCheck):
+		result.Status = models.HealthStatusSkipped
+	case err != nil:
+		result.Status = models.HealthStatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// checkConfig 检查 Server-To-Server OAuth 凭据是否完整
+func (h *HealthHandler) checkConfig(ctx context.Context) error {
+	if h.cfg.ZoomAccountID == "" || h.cfg.ZoomClientID == "" || h.cfg.ZoomClientSecret == "" {
+		return errors.New("ZOOM_ACCOUNT_ID, ZOOM_CLIENT_ID and ZOOM_CLIENT_SECRET must be set")
+	}
+	return nil
+}
+
+// checkZoomOAuth 检查能否获取Zoom OAuth令牌，令牌有效时直接使用缓存
+// 获取失败后在 zoomOAuthFailureTTL 内直接返回上次的错误，不再请求Zoom；
+// 探测本身超时或被取消不代表令牌接口故障，不缓存
+func (h *HealthHandler) checkZoomOAuth(ctx context.Context) error {
+	if err := h.checkConfig(ctx); err != nil {
+		return errors.New("OAuth credentials not configured")
+	}
+
+	h.oauthMu.Lock()
+	if h.oauthErr != nil && time.Since(h.oauthFailedAt) < zoomOAuthFailureTTL {
+		err := h.oauthErr
+		h.oauthMu.Unlock()
+		return err
+	}
+	h.oauthMu.Unlock()
+
+	_, err := h.zoomService.GetOAuthToken(ctx)
+	if err != nil && (ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+		return err
+	}
+
+	h.oauthMu.Lock()
+	defer h.oauthMu.Unlock()
+	h.oauthErr = err
+	if err != nil {
+		h.oauthFailedAt = time.Now()
+	}
+	return err
+}
+
+// checkDooTask 检查DooTask是否可达，禁用DooTask认证时跳过
+func (h *HealthHandler) checkDooTask(ctx context.Context) error {
+	if h.cfg.DisableDooTaskAuth {
+		return errSkipCheck
+	}
+	return h.dooTask.Ping(ctx)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"zoom-app-server/config"
+	"zoom-app-server/models"
+	"zoom-app-server/services"
+)
+
+// fakeOAuthClient 只实现 GetOAuthToken，依次返回 errs 中的错误
+type fakeOAuthClient struct {
+	services.ZoomClient
+	errs  []error
+	calls int
+}
+
+func (f *fakeOAuthClient) GetOAuthToken(ctx context.Context) (*models.OAuthTokenResponse, error) {
+	err := f.errs[f.calls]
+	f.calls++
+	if err != nil {
+		return nil, err
+	}
+	return &models.OAuthTokenResponse{AccessToken: "token"}, nil
+}
+
+func TestCheckZoomOAuthCachesOnlyEndpointErrors(t *testing.T) {
+	errEndpoint := errors.New("invalid client")
+	tests := []struct {
+		name      string
+		errs      []error
+		wantCalls int
+	}{
+		{"endpoint error is cached", []error{errEndpoint, nil}, 1},
+		{"deadline exceeded is not cached", []error{context.DeadlineExceeded, nil}, 2},
+		{"canceled is not cached", []error{context.Canceled, nil}, 2},
+		{"success is not cached as failure", []error{nil, nil}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeOAuthClient{errs: tt.errs}
+			h := NewHealthHandler(&config.Config{
+				ZoomAccountID:    "account",
+				ZoomClientID:     "client",
+				ZoomClientSecret: "secret",
+			}, client, nil, nil)
+
+			if err := h.checkZoomOAuth(context.Background()); !errors.Is(err, tt.errs[0]) {
+				t.Fatalf("first check = %v, want %v", err, tt.errs[0])
+			}
+			h.checkZoomOAuth(context.Background())
+			if client.calls != tt.wantCalls {
+				t.Errorf("token endpoint called %d times, want %d", client.calls, tt.wantCalls)
+			}
+		})
+	}
+
+	// 探测自身的上下文已取消时不缓存，即使返回的错误没有包装上下文错误
+	client := &fakeOAuthClient{errs: []error{errEndpoint, nil}}
+	h := NewHealthHandler(&config.Config{
+		ZoomAccountID:    "account",
+		ZoomClientID:     "client",
+		ZoomClientSecret: "secret",
+	}, client, nil, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	h.checkZoomOAuth(ctx)
+	if err := h.checkZoomOAuth(context.Background()); err != nil {
+		t.Errorf("check after canceled probe = %v, want fresh success", err)
+	}
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"zoom-app-server/models"
+	"zoom-app-server/services"
+	"zoom-app-server/utils/logger"
+	"zoom-app-server/utils/response"
+)
+
+// maxWebhookBodySize Webhook请求体大小上限
+const maxWebhookBodySize = 1 << 20
+
+// WebhookHandler Zoom Webhook 处理器
+type WebhookHandler struct {
+	webhookService *services.WebhookService
+}
+
+// NewWebhookHandler 创建新的Webhook处理器实例
+func NewWebhookHandler(webhookService *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// HandleZoomWebhook 处理Zoom推送的事件
+func (h *WebhookHandler) HandleZoomWebhook(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	log.Debug("Handling Zoom webhook request")
+
+	if !h.webhookService.Enabled() {
+		log.Warn("Zoom webhook received but ZOOM_WEBHOOK_SECRET_TOKEN is not configured")
+		response.WriteError(w, http.StatusServiceUnavailable, 503, "Webhook未配置")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		log.WithError(err).Error("Failed to read webhook body")
+		response.WriteBadRequest(w, "请求体读取失败")
+		return
+	}
+
+	signature := r.Header.Get("x-zm-signature")
+	timestamp := r.Header.Get("x-zm-request-timestamp")
+	if err := h.webhookService.VerifySignature(signature, timestamp, body); err != nil {
+		log.WithError(err).WithField("timestamp", timestamp).Warn("Rejected Zoom webhook request")
+		if errors.Is(err, services.ErrWebhookTimestampExpired) {
+			response.WriteUnauthorized(w, "请求已过期")
+			return
+		}
+		response.WriteUnauthorized(w, "签名校验失败")
+		return
+	}
+
+	var event models.WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		log.WithError(err).Error("Failed to decode webhook event")
+		response.WriteBadRequest(w, "请求参数格式错误")
+		return
+	}
+
+	// URL验证需要直接返回Zoom要求的结构
+	if event.Event == models.WebhookEventURLValidation {
+		validation, err := h.webhookService.ValidateURL(&event)
+		if err != nil {
+			log.WithError(err).Error("Failed to answer webhook URL validation")
+			response.WriteBadRequest(w, "URL验证参数错误")
+			return
+		}
+		log.Info("Answered Zoom webhook URL validation challenge")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(validation)
+		return
+	}
+
+	// Zoom 不会重发4xx的事件，只有内容无效时返回400，内部错误返回500以便Zoom重发
+	if err := h.webhookService.HandleEvent(&event); err != nil {
+		log.WithError(err).WithField("event", event.Event).Error("Failed to handle webhook event")
+		if errors.Is(err, services.ErrWebhookPayloadInvalid) {
+			response.WriteBadRequest(w, "事件内容格式错误")
+			return
+		}
+		response.WriteInternalError(w, "事件处理失败")
+		return
+	}
+
+	response.WriteSuccess(w, nil, "事件已接收")
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"zoom-app-server/config"
+	"zoom-app-server/services"
+	"zoom-app-server/store"
+)
+
+func TestHandleZoomWebhookStatusCodes(t *testing.T) {
+	const secret = "webhook-secret"
+	meetingStore, err := store.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer meetingStore.Close()
+	h := NewWebhookHandler(services.NewWebhookService(&config.Config{
+		ZoomWebhookSecretToken: secret,
+		ZoomWebhookMaxSkew:     300,
+	}, meetingStore))
+
+	post := func(body string, sign bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/webhooks/zoom", strings.NewReader(body))
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte("v0:" + timestamp + ":" + body))
+		req.Header.Set("x-zm-request-timestamp", timestamp)
+		if sign {
+			req.Header.Set("x-zm-signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+		}
+		rec := httptest.NewRecorder()
+		h.HandleZoomWebhook(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		unsigned   bool
+		wantStatus int
+	}{
+		{"url validation", `{"event":"endpoint.url_validation","payload":{"plainToken":"abc"}}`, false, http.StatusOK},
+		{"meeting event", `{"event":"meeting.started","payload":{"object":{"id":"85000000001"}}}`, false, http.StatusOK},
+		{"unsigned request", `{"event":"meeting.started","payload":{"object":{"id":"85000000001"}}}`, true, http.StatusUnauthorized},
+		{"malformed body", `not json`, false, http.StatusBadRequest},
+		{"malformed payload", `{"event":"meeting.started","payload":{"object":{"id":"abc"}}}`, false, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := post(tt.body, !tt.unsigned); rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+
+	// 数据库故障返回500，Zoom会重发
+	meetingStore.Close()
+	if rec := post(`{"event":"meeting.ended","payload":{"object":{"id":"85000000001"}}}`, true); rec.Code != http.StatusInternalServerError {
+		t.Errorf("store failure: status = %d, want 500", rec.Code)
+	}
+}
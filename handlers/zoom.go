@@ -1,106 +1,315 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 	"zoom-app-server/config"
+	"zoom-app-server/metrics"
+	"zoom-app-server/middleware"
 	"zoom-app-server/models"
 	"zoom-app-server/services"
+	"zoom-app-server/store"
+	"zoom-app-server/tracing"
 	"zoom-app-server/utils/logger"
 	"zoom-app-server/utils/response"
 )
 
 // ZoomHandler Zoom处理器
 type ZoomHandler struct {
-	cfg         *config.Config
-	zoomService *services.ZoomService
+	cfg             *config.Config
+	zoomService     services.ZoomClient
+	meetingStore    *store.Store
+	signaturePolicy *services.SignaturePolicy
 }
 
 // NewZoomHandler 创建新的Zoom处理器实例
-func NewZoomHandler(cfg *config.Config, zoomService *services.ZoomService) *ZoomHandler {
+func NewZoomHandler(cfg *config.Config, zoomService services.ZoomClient, meetingStore *store.Store) *ZoomHandler {
+	if cfg.DisableDooTaskAuth {
+		logger.Warn("DooTask auth disabled, meeting endpoints are not restricted to creators, hosts and admins")
+	}
 	return &ZoomHandler{
-		cfg:         cfg,
-		zoomService: zoomService,
+		cfg:             cfg,
+		zoomService:     zoomService,
+		meetingStore:    meetingStore,
+		signaturePolicy: services.NewSignaturePolicy(zoomService, meetingStore),
 	}
 }
 
 // HandleGenerateSignature 处理生成签名请求
 func (h *ZoomHandler) HandleGenerateSignature(w http.ResponseWriter, r *http.Request) {
-	logger.WithFields(logrus.Fields{
-		"method": r.Method,
-		"path":   r.URL.Path,
-		"remote": r.RemoteAddr,
-	}).Info("Handling generate signature request")
-	
+	log := logger.FromContext(r.Context())
+	log.Info("Handling generate signature request")
+
 	var req models.ZoomSignatureRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		logger.WithError(err).Error("Failed to decode signature request")
+		log.WithError(err).Error("Failed to decode signature request")
 		response.WriteBadRequest(w, "请求参数格式错误")
 		return
 	}
 
-	logger.WithFields(logrus.Fields{
+	if req.MeetingNumber == "" {
+		response.WriteBadRequest(w, "会议号不能为空")
+		return
+	}
+
+	// 根据用户身份决定实际签发的角色
+	requester := requesterFromContext(r)
+	trace.SpanFromContext(r.Context()).SetAttributes(tracing.AttrMeetingID.String(req.MeetingNumber))
+	role := h.signaturePolicy.ResolveRole(r.Context(), requester, req.MeetingNumber, req.Role)
+
+	log.WithFields(logrus.Fields{
 		"meeting_number": req.MeetingNumber,
-		"role":           req.Role,
+		"requested_role": req.Role,
+		"role":           role,
 	}).Debug("Generating signature for meeting")
 
-	signature, err := h.zoomService.GenerateSignature(req.MeetingNumber, req.Role)
+	signature, err := h.zoomService.GenerateSignature(req.MeetingNumber, role, req.VideoWebRTCMode)
 	if err != nil {
-		logger.WithError(err).WithFields(logrus.Fields{
+		log.WithError(err).WithFields(logrus.Fields{
 			"meeting_number": req.MeetingNumber,
-			"role":           req.Role,
+			"role":           role,
 		}).Error("Failed to generate signature")
 		response.WriteInternalError(w, "生成签名失败")
 		return
 	}
+	metrics.SignaturesIssued.WithLabelValues(metrics.RoleLabel(req.Role), metrics.RoleLabel(role)).Inc()
 
 	responseData := models.ZoomSignatureResponse{
 		Signature: signature,
+		Role:      role,
+	}
+
+	// 登录用户附带ZAK/OBF令牌，获取失败不影响签名返回
+	if userInfo, ok := middleware.UserInfoFromContext(r.Context()); ok {
+		if zoomUserID := h.zoomUserFor(userInfo); zoomUserID != "" {
+			if role == services.SignatureRoleHost {
+				zak, err := h.zoomService.GetZAKToken(r.Context(), zoomUserID)
+				if err != nil {
+					log.WithError(err).WithField("zoom_user", zoomUserID).Warn("Failed to get ZAK token")
+				}
+				responseData.ZAK = zak
+			}
+			if req.IncludeOBF {
+				obf, err := h.zoomService.GetOBFToken(r.Context(), zoomUserID, req.MeetingNumber)
+				if err != nil {
+					log.WithError(err).WithField("zoom_user", zoomUserID).Warn("Failed to get OBF token")
+				}
+				responseData.OBF = obf
+			}
+		}
 	}
 
-	logger.WithField("meeting_number", req.MeetingNumber).Info("Signature generated successfully")
+	log.WithField("meeting_number", req.MeetingNumber).Info("Signature generated successfully")
 	response.WriteSuccess(w, responseData, "签名生成成功")
 }
 
+// HandleGenerateVideoSDKToken 处理生成Video SDK会话令牌请求
+func (h *ZoomHandler) HandleGenerateVideoSDKToken(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	log.Info("Handling generate Video SDK token request")
+
+	var req models.VideoSDKTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.WithError(err).Error("Failed to decode Video SDK token request")
+		response.WriteBadRequest(w, "请求参数格式错误")
+		return
+	}
+	if req.SessionName == "" || len(req.SessionName) > 200 {
+		response.WriteBadRequest(w, "sessionName 不能为空且不能超过200个字符")
+		return
+	}
+	if len(req.SessionKey) > 36 {
+		response.WriteBadRequest(w, "sessionKey 不能超过36个字符")
+		return
+	}
+	if req.Role != services.SignatureRoleParticipant && req.Role != services.SignatureRoleHost {
+		response.WriteBadRequest(w, "role 只支持 0(参与者) 或 1(主持人)")
+		return
+	}
+	if req.CloudRecordingOption != nil && *req.CloudRecordingOption != 0 && *req.CloudRecordingOption != 1 {
+		response.WriteBadRequest(w, "cloudRecordingOption 只支持 0 或 1")
+		return
+	}
+
+	// 使用DooTask用户ID标识会话参与者
+	var userIdentity string
+	if userID := middleware.UserIDFromContext(r.Context()); userID != 0 {
+		userIdentity = strconv.Itoa(userID)
+	}
+
+	token, expiresAt, err := h.zoomService.GenerateVideoSDKToken(&req, userIdentity)
+	if err != nil {
+		log.WithError(err).WithField("session_name", req.SessionName).Error("Failed to generate Video SDK token")
+		response.WriteInternalError(w, "生成Video SDK令牌失败")
+		return
+	}
+
+	log.WithFields(logrus.Fields{
+		"session_name":  req.SessionName,
+		"role":          req.Role,
+		"user_identity": userIdentity,
+	}).Info("Video SDK token generated successfully")
+	response.WriteSuccess(w, models.VideoSDKTokenResponse{
+		Token:        token,
+		SessionName:  req.SessionName,
+		UserIdentity: userIdentity,
+		ExpiresAt:    expiresAt,
+	}, "Video SDK令牌生成成功")
+}
+
+// HandleGetZAKToken 处理获取ZAK令牌请求
+func (h *ZoomHandler) HandleGetZAKToken(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	log.Info("Handling get ZAK token request")
+
+	userInfo, ok := middleware.UserInfoFromContext(r.Context())
+	if !ok {
+		response.WriteUnauthorized(w, "未获取到用户信息")
+		return
+	}
+	zoomUserID := h.zoomUserFor(userInfo)
+	if zoomUserID == "" {
+		response.WriteBadRequest(w, "当前用户未关联Zoom账号")
+		return
+	}
+
+	token, err := h.zoomService.GetZAKToken(r.Context(), zoomUserID)
+	if err != nil {
+		log.WithError(err).WithField("zoom_user", zoomUserID).Error("Failed to get ZAK token")
+		writeZoomError(w, err, "获取ZAK令牌失败")
+		return
+	}
+
+	response.WriteSuccess(w, models.ZoomUserTokenResponse{Token: token}, "获取ZAK令牌成功")
+}
+
+// HandleGetOBFToken 处理获取OBF令牌请求
+func (h *ZoomHandler) HandleGetOBFToken(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	meetingNumber := r.URL.Query().Get("meetingNumber")
+	log.WithFields(logrus.Fields{
+		"meeting_number": meetingNumber,
+	}).Info("Handling get OBF token request")
+
+	if meetingNumber == "" {
+		response.WriteBadRequest(w, "会议号不能为空")
+		return
+	}
+	userInfo, ok := middleware.UserInfoFromContext(r.Context())
+	if !ok {
+		response.WriteUnauthorized(w, "未获取到用户信息")
+		return
+	}
+	zoomUserID := h.zoomUserFor(userInfo)
+	if zoomUserID == "" {
+		response.WriteBadRequest(w, "当前用户未关联Zoom账号")
+		return
+	}
+
+	token, err := h.zoomService.GetOBFToken(r.Context(), zoomUserID, meetingNumber)
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"zoom_user":      zoomUserID,
+			"meeting_number": meetingNumber,
+		}).Error("Failed to get OBF token")
+		writeZoomError(w, err, "获取OBF令牌失败")
+		return
+	}
+
+	response.WriteSuccess(w, models.ZoomUserTokenResponse{Token: token}, "获取OBF令牌成功")
+}
+
+// requesterFromContext 返回当前登录的DooTask用户，未登录时返回nil
+func requesterFromContext(r *http.Request) *services.SignatureRequester {
+	userInfo, ok := middleware.UserInfoFromContext(r.Context())
+	if !ok {
+		return nil
+	}
+	return &services.SignatureRequester{
+		UserID:  userInfo.Userid,
+		Email:   userInfo.VerifiedEmail(),
+		IsAdmin: userInfo.IsAdmin(),
+	}
+}
+
+// authorizeMeeting 校验当前用户能否操作会议（创建者、Zoom主持人或管理员），不能时返回403
+// 禁用DooTask认证时没有用户身份，不做限制
+func (h *ZoomHandler) authorizeMeeting(w http.ResponseWriter, r *http.Request, meetingID string) bool {
+	if h.cfg.DisableDooTaskAuth {
+		return true
+	}
+	if h.signaturePolicy.CanManageMeeting(r.Context(), requesterFromContext(r), meetingID) {
+		return true
+	}
+	response.WriteForbidden(w, "无权操作该会议")
+	return false
+}
+
+// zoomUserFor 返回DooTask用户对应的Zoom用户ID或邮箱，未配置映射时只使用已验证的邮箱
+func (h *ZoomHandler) zoomUserFor(userInfo *middleware.UserInfoResp) string {
+	if zoomUserID, ok := h.cfg.ZoomUserMapping[userInfo.Userid]; ok {
+		return zoomUserID
+	}
+	return userInfo.VerifiedEmail()
+}
+
 // HandleGetConfig 处理获取配置请求
 func (h *ZoomHandler) HandleGetConfig(w http.ResponseWriter, r *http.Request) {
-	logger.WithFields(logrus.Fields{
-		"method": r.Method,
-		"path":   r.URL.Path,
-		"remote": r.RemoteAddr,
-	}).Info("Handling get config request")
-	
+	log := logger.FromContext(r.Context())
+	log.Info("Handling get config request")
+
 	responseData := models.ConfigResponse{
 		DisableJoinMeeting: h.cfg.DisableJoinMeeting,
 	}
 
-	logger.Debug("Config retrieved successfully")
+	log.Debug("Config retrieved successfully")
 	response.WriteSuccess(w, responseData, "获取配置成功")
 }
 
 // HandleCreateMeeting 处理创建会议请求
 func (h *ZoomHandler) HandleCreateMeeting(w http.ResponseWriter, r *http.Request) {
-	logger.WithFields(logrus.Fields{
-		"method": r.Method,
-		"path":   r.URL.Path,
-		"remote": r.RemoteAddr,
-	}).Info("Handling create meeting request")
-	
+	log := logger.FromContext(r.Context())
+	log.Info("Handling create meeting request")
+
 	var req models.CreateMeetingRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		logger.WithError(err).Error("Failed to decode create meeting request")
+		log.WithError(err).Error("Failed to decode create meeting request")
 		response.WriteBadRequest(w, "请求参数格式错误")
 		return
 	}
-	
+
 	// 验证必要的OAuth配置
 	if h.cfg.ZoomAccountID == "" || h.cfg.ZoomClientID == "" || h.cfg.ZoomClientSecret == "" {
 		response.WriteInternalError(w, "服务器OAuth配置未完成")
 		return
 	}
-	
+
+	// 验证定期会议规则
+	if req.Type == models.MeetingTypeRecurringFixedTime && req.Recurrence == nil {
+		response.WriteBadRequest(w, "固定时间的定期会议必须设置 recurrence")
+		return
+	}
+	if req.Recurrence != nil {
+		if req.Type != models.MeetingTypeRecurringFixedTime {
+			response.WriteBadRequest(w, "只有固定时间的定期会议(type=8)可以设置 recurrence")
+			return
+		}
+		if msg := validateRecurrence(req.Recurrence); msg != "" {
+			response.WriteBadRequest(w, msg)
+			return
+		}
+	}
+
 	// 设置默认值
 	if req.Duration == 0 {
 		req.Duration = 60 // 默认60分钟
@@ -108,7 +317,7 @@ func (h *ZoomHandler) HandleCreateMeeting(w http.ResponseWriter, r *http.Request
 	if req.Timezone == "" {
 		req.Timezone = "Asia/Shanghai" // 默认北京时间
 	}
-	
+
 	// 添加默认会议设置
 	if req.Settings == nil {
 		req.Settings = &models.MeetingSettings{
@@ -119,34 +328,570 @@ func (h *ZoomHandler) HandleCreateMeeting(w http.ResponseWriter, r *http.Request
 			WaitingRoom:      false,
 		}
 	}
-	
-	// 获取访问令牌
-	logger.Debug("Getting OAuth token for meeting creation")
-	tokenResp, err := h.zoomService.GetOAuthToken()
-	if err != nil {
-		logger.WithError(err).Error("Failed to get OAuth token")
-		response.WriteInternalError(w, "Zoom认证失败")
-		return
-	}
-	
+
 	// 创建会议
-	logger.WithFields(logrus.Fields{
+	log.WithFields(logrus.Fields{
 		"topic":    req.Topic,
 		"duration": req.Duration,
 		"timezone": req.Timezone,
 	}).Info("Creating Zoom meeting")
-	meetingResp, err := h.zoomService.CreateMeeting(tokenResp.AccessToken, &req)
+	meetingResp, err := h.zoomService.CreateMeeting(r.Context(), &req)
 	if err != nil {
-		logger.WithError(err).WithField("topic", req.Topic).Error("Failed to create meeting")
-		response.WriteInternalError(w, "创建会议失败")
+		log.WithError(err).WithField("topic", req.Topic).Error("Failed to create meeting")
+		writeZoomError(w, err, "创建会议失败")
 		return
 	}
-	
-	logger.WithFields(logrus.Fields{
+
+	log.WithFields(logrus.Fields{
 		"meeting_id": meetingResp.ID,
 		"topic":      meetingResp.Topic,
 		"join_url":   meetingResp.JoinURL,
 	}).Info("Meeting created successfully")
-	
+	metrics.MeetingsCreated.WithLabelValues(strconv.Itoa(meetingResp.Type)).Inc()
+	tracing.SetMeetingID(r.Context(), meetingResp.ID)
+
+	// 保存会议记录，失败不影响本次创建结果
+	creatorUserID := middleware.UserIDFromContext(r.Context())
+	record := models.NewMeetingRecord(meetingResp, creatorUserID)
+	if err := h.meetingStore.SaveMeeting(record); err != nil {
+		log.WithError(err).WithField("meeting_id", meetingResp.ID).Error("Failed to save meeting record")
+	}
+
 	response.WriteSuccess(w, meetingResp, "会议创建成功")
-}
\ No newline at end of file
+}
+
+// listMeetingTypes Zoom支持的会议列表类型，值为从本地会议记录查询时对应的范围
+var listMeetingTypes = map[string]string{
+	"":                  models.MeetingScopeAll,
+	"scheduled":         models.MeetingScopeAll,
+	"live":              models.MeetingScopeLive,
+	"upcoming":          models.MeetingScopeUpcoming,
+	"upcoming_meetings": models.MeetingScopeUpcoming,
+	"previous_meetings": models.MeetingScopePast,
+}
+
+// defaultListPageSize 会议列表默认每页数量，与Zoom一致
+const defaultListPageSize = 30
+
+// HandleGetMeeting 处理获取会议详情请求
+func (h *ZoomHandler) HandleGetMeeting(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	meetingID := mux.Vars(r)["id"]
+	log.WithFields(logrus.Fields{
+		"meeting_id": meetingID,
+	}).Info("Handling get meeting request")
+
+	if !h.authorizeMeeting(w, r, meetingID) {
+		return
+	}
+
+	meetingResp, err := h.zoomService.GetMeeting(r.Context(), meetingID)
+	if err != nil {
+		log.WithError(err).WithField("meeting_id", meetingID).Error("Failed to get meeting")
+		writeZoomError(w, err, "获取会议失败")
+		return
+	}
+
+	response.WriteSuccess(w, meetingResp, "获取会议成功")
+}
+
+// HandleListMeetings 处理获取会议列表请求
+func (h *ZoomHandler) HandleListMeetings(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	log.Info("Handling list meetings request")
+
+	query := r.URL.Query()
+	req := models.ListMeetingsRequest{
+		Type:          query.Get("type"),
+		NextPageToken: query.Get("next_page_token"),
+		From:          query.Get("from"),
+		To:            query.Get("to"),
+	}
+	if _, ok := listMeetingTypes[req.Type]; !ok {
+		response.WriteBadRequest(w, "会议类型不支持")
+		return
+	}
+	if v := query.Get("page_size"); v != "" {
+		pageSize, err := strconv.Atoi(v)
+		if err != nil || pageSize <= 0 || pageSize > 300 {
+			response.WriteBadRequest(w, "page_size 必须在 1-300 之间")
+			return
+		}
+		req.PageSize = pageSize
+	}
+	if v := query.Get("page_number"); v != "" {
+		pageNumber, err := strconv.Atoi(v)
+		if err != nil || pageNumber <= 0 {
+			response.WriteBadRequest(w, "page_number 必须为正整数")
+			return
+		}
+		req.PageNumber = pageNumber
+	}
+
+	// 非管理员只能看到自己创建的会议，从本地会议记录分页查询，保证分页和总数准确
+	// 禁用DooTask认证时没有用户身份，直接返回Zoom的会议列表
+	if requester := requesterFromContext(r); !h.cfg.DisableDooTaskAuth && (requester == nil || !requester.IsAdmin) {
+		h.listOwnMeetings(w, r, requester, &req)
+		return
+	}
+
+	listResp, err := h.zoomService.ListMeetings(r.Context(), &req)
+	if err != nil {
+		log.WithError(err).WithField("type", req.Type).Error("Failed to list meetings")
+		writeZoomError(w, err, "获取会议列表失败")
+		return
+	}
+
+	response.WriteSuccess(w, listResp, "获取会议列表成功")
+}
+
+// listOwnMeetings 从本地会议记录分页查询用户创建的会议，按Zoom会议列表格式返回
+// 本地分页只支持 page_number，不支持 next_page_token
+func (h *ZoomHandler) listOwnMeetings(w http.ResponseWriter, r *http.Request, requester *services.SignatureRequester, req *models.ListMeetingsRequest) {
+	log := logger.FromContext(r.Context())
+
+	if req.NextPageToken != "" {
+		response.WriteBadRequest(w, "请使用 page_number 分页")
+		return
+	}
+	filter := models.MyMeetingsFilter{
+		Scope:    listMeetingTypes[req.Type],
+		Page:     1,
+		PageSize: defaultListPageSize,
+	}
+	if req.PageNumber > 0 {
+		filter.Page = req.PageNumber
+	}
+	if req.PageSize > 0 {
+		filter.PageSize = req.PageSize
+	}
+	if req.From != "" {
+		from, err := parseDateParam(req.From, false)
+		if err != nil {
+			response.WriteBadRequest(w, "from 必须为 yyyy-mm-dd 或 ISO 8601 格式")
+			return
+		}
+		filter.From = from
+	}
+	if req.To != "" {
+		to, err := parseDateParam(req.To, true)
+		if err != nil {
+			response.WriteBadRequest(w, "to 必须为 yyyy-mm-dd 或 ISO 8601 格式")
+			return
+		}
+		filter.To = to
+	}
+
+	listResp := models.ListMeetingsResponse{
+		PageNumber: filter.Page,
+		PageSize:   filter.PageSize,
+		Meetings:   []models.MeetingListItem{},
+	}
+	if requester != nil {
+		// 不传邮箱，只查询用户创建的会议
+		filter.UserID = requester.UserID
+		records, total, err := h.meetingStore.ListUserMeetings(&filter)
+		if err != nil {
+			log.WithError(err).WithField("user_id", requester.UserID).Error("Failed to list meetings created by user")
+			response.WriteInternalError(w, "获取会议列表失败")
+			return
+		}
+		for _, item := range records {
+			listResp.Meetings = append(listResp.Meetings, item.MeetingRecord.ListItem())
+		}
+		listResp.TotalRecords = total
+		listResp.PageCount = (total + filter.PageSize - 1) / filter.PageSize
+	}
+
+	response.WriteSuccess(w, listResp, "获取会议列表成功")
+}
+
+// HandleUpdateMeeting 处理更新会议请求
+func (h *ZoomHandler) HandleUpdateMeeting(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	meetingID := mux.Vars(r)["id"]
+	log.WithFields(logrus.Fields{
+		"meeting_id": meetingID,
+	}).Info("Handling update meeting request")
+
+	if !h.authorizeMeeting(w, r, meetingID) {
+		return
+	}
+
+	var req models.UpdateMeetingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.WithError(err).Error("Failed to decode update meeting request")
+		response.WriteBadRequest(w, "请求参数格式错误")
+		return
+	}
+	if req.Recurrence != nil {
+		if msg := validateRecurrence(req.Recurrence); msg != "" {
+			response.WriteBadRequest(w, msg)
+			return
+		}
+	}
+
+	if err := h.zoomService.UpdateMeeting(r.Context(), meetingID, &req); err != nil {
+		log.WithError(err).WithField("meeting_id", meetingID).Error("Failed to update meeting")
+		writeZoomError(w, err, "更新会议失败")
+		return
+	}
+
+	log.WithField("meeting_id", meetingID).Info("Meeting updated successfully")
+	h.syncMeetingRecord(r.Context(), meetingID)
+	response.WriteSuccess(w, nil, "会议更新成功")
+}
+
+// HandleDeleteMeeting 处理删除会议请求
+func (h *ZoomHandler) HandleDeleteMeeting(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	meetingID := mux.Vars(r)["id"]
+	log.WithFields(logrus.Fields{
+		"meeting_id": meetingID,
+	}).Info("Handling delete meeting request")
+
+	if !h.authorizeMeeting(w, r, meetingID) {
+		return
+	}
+
+	if err := h.zoomService.DeleteMeeting(r.Context(), meetingID); err != nil {
+		log.WithError(err).WithField("meeting_id", meetingID).Error("Failed to delete meeting")
+		writeZoomError(w, err, "删除会议失败")
+		return
+	}
+
+	log.WithField("meeting_id", meetingID).Info("Meeting deleted successfully")
+	h.syncMeetingStatus(meetingID, models.MeetingStatusDeleted)
+	response.WriteSuccess(w, nil, "会议删除成功")
+}
+
+// HandleUpdateMeetingStatus 处理更新会议状态请求（结束会议）
+func (h *ZoomHandler) HandleUpdateMeetingStatus(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	meetingID := mux.Vars(r)["id"]
+	log.WithFields(logrus.Fields{
+		"meeting_id": meetingID,
+	}).Info("Handling update meeting status request")
+
+	if !h.authorizeMeeting(w, r, meetingID) {
+		return
+	}
+
+	var req models.UpdateMeetingStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.WithError(err).Error("Failed to decode update meeting status request")
+		response.WriteBadRequest(w, "请求参数格式错误")
+		return
+	}
+	if req.Action == "" {
+		req.Action = "end"
+	}
+	if req.Action != "end" && req.Action != "recover" {
+		response.WriteBadRequest(w, "action 只支持 end 或 recover")
+		return
+	}
+
+	if err := h.zoomService.UpdateMeetingStatus(r.Context(), meetingID, &req); err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"meeting_id": meetingID,
+			"action":     req.Action,
+		}).Error("Failed to update meeting status")
+		writeZoomError(w, err, "更新会议状态失败")
+		return
+	}
+
+	log.WithFields(logrus.Fields{
+		"meeting_id": meetingID,
+		"action":     req.Action,
+	}).Info("Meeting status updated successfully")
+	if req.Action == "end" {
+		h.syncMeetingStatus(meetingID, models.MeetingStatusEnded)
+	} else {
+		h.syncMeetingStatus(meetingID, models.MeetingStatusWaiting)
+	}
+	response.WriteSuccess(w, nil, "会议状态更新成功")
+}
+
+// HandleListOccurrences 处理获取定期会议单次会议列表请求
+func (h *ZoomHandler) HandleListOccurrences(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	meetingID := mux.Vars(r)["id"]
+	log.WithFields(logrus.Fields{
+		"meeting_id": meetingID,
+	}).Info("Handling list occurrences request")
+
+	if !h.authorizeMeeting(w, r, meetingID) {
+		return
+	}
+
+	showPrevious := r.URL.Query().Get("show_previous") == "true"
+	occurrencesResp, err := h.zoomService.ListOccurrences(r.Context(), meetingID, showPrevious)
+	if err != nil {
+		log.WithError(err).WithField("meeting_id", meetingID).Error("Failed to list occurrences")
+		writeZoomError(w, err, "获取定期会议列表失败")
+		return
+	}
+
+	response.WriteSuccess(w, occurrencesResp, "获取定期会议列表成功")
+}
+
+// HandleUpdateOccurrence 处理更新单次会议请求
+func (h *ZoomHandler) HandleUpdateOccurrence(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	vars := mux.Vars(r)
+	meetingID, occurrenceID := vars["id"], vars["occurrence_id"]
+	log.WithFields(logrus.Fields{
+		"meeting_id":    meetingID,
+		"occurrence_id": occurrenceID,
+	}).Info("Handling update occurrence request")
+
+	if !h.authorizeMeeting(w, r, meetingID) {
+		return
+	}
+
+	var req models.UpdateOccurrenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.WithError(err).Error("Failed to decode update occurrence request")
+		response.WriteBadRequest(w, "请求参数格式错误")
+		return
+	}
+
+	if err := h.zoomService.UpdateOccurrence(r.Context(), meetingID, occurrenceID, &req); err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"meeting_id":    meetingID,
+			"occurrence_id": occurrenceID,
+		}).Error("Failed to update occurrence")
+		writeZoomError(w, err, "更新单次会议失败")
+		return
+	}
+
+	log.WithFields(logrus.Fields{
+		"meeting_id":    meetingID,
+		"occurrence_id": occurrenceID,
+	}).Info("Occurrence updated successfully")
+	response.WriteSuccess(w, nil, "单次会议更新成功")
+}
+
+// HandleDeleteOccurrence 处理删除单次会议请求
+func (h *ZoomHandler) HandleDeleteOccurrence(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	vars := mux.Vars(r)
+	meetingID, occurrenceID := vars["id"], vars["occurrence_id"]
+	log.WithFields(logrus.Fields{
+		"meeting_id":    meetingID,
+		"occurrence_id": occurrenceID,
+	}).Info("Handling delete occurrence request")
+
+	if !h.authorizeMeeting(w, r, meetingID) {
+		return
+	}
+
+	if err := h.zoomService.DeleteOccurrence(r.Context(), meetingID, occurrenceID); err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"meeting_id":    meetingID,
+			"occurrence_id": occurrenceID,
+		}).Error("Failed to delete occurrence")
+		writeZoomError(w, err, "删除单次会议失败")
+		return
+	}
+
+	log.WithFields(logrus.Fields{
+		"meeting_id":    meetingID,
+		"occurrence_id": occurrenceID,
+	}).Info("Occurrence deleted successfully")
+	response.WriteSuccess(w, nil, "单次会议删除成功")
+}
+
+// HandleListMyMeetings 处理获取当前用户会议列表请求
+func (h *ZoomHandler) HandleListMyMeetings(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	log.Info("Handling list my meetings request")
+
+	userInfo, ok := middleware.UserInfoFromContext(r.Context())
+	if !ok {
+		response.WriteUnauthorized(w, "未获取到用户信息")
+		return
+	}
+	userID := userInfo.Userid
+
+	query := r.URL.Query()
+	filter := models.MyMeetingsFilter{
+		UserID:   userID,
+		Email:    userInfo.VerifiedEmail(),
+		Scope:    query.Get("status"),
+		Page:     1,
+		PageSize: 20,
+	}
+	switch filter.Scope {
+	case "":
+		filter.Scope = models.MeetingScopeAll
+	case models.MeetingScopeAll, models.MeetingScopeUpcoming, models.MeetingScopeLive, models.MeetingScopePast:
+	default:
+		response.WriteBadRequest(w, "status 只支持 all、upcoming、live、past")
+		return
+	}
+	if v := query.Get("page"); v != "" {
+		page, err := strconv.Atoi(v)
+		if err != nil || page <= 0 {
+			response.WriteBadRequest(w, "page 必须为正整数")
+			return
+		}
+		filter.Page = page
+	}
+	if v := query.Get("page_size"); v != "" {
+		pageSize, err := strconv.Atoi(v)
+		if err != nil || pageSize <= 0 || pageSize > 100 {
+			response.WriteBadRequest(w, "page_size 必须在 1-100 之间")
+			return
+		}
+		filter.PageSize = pageSize
+	}
+	if v := query.Get("from"); v != "" {
+		from, err := parseDateParam(v, false)
+		if err != nil {
+			response.WriteBadRequest(w, "from 必须为 yyyy-mm-dd 或 ISO 8601 格式")
+			return
+		}
+		filter.From = from
+	}
+	if v := query.Get("to"); v != "" {
+		to, err := parseDateParam(v, true)
+		if err != nil {
+			response.WriteBadRequest(w, "to 必须为 yyyy-mm-dd 或 ISO 8601 格式")
+			return
+		}
+		filter.To = to
+	}
+
+	meetings, total, err := h.meetingStore.ListUserMeetings(&filter)
+	if err != nil {
+		log.WithError(err).WithField("user_id", userID).Error("Failed to list user meetings")
+		response.WriteInternalError(w, "获取我的会议失败")
+		return
+	}
+
+	response.WriteSuccess(w, models.MyMeetingsResponse{
+		Page:         filter.Page,
+		PageSize:     filter.PageSize,
+		TotalRecords: total,
+		Meetings:     meetings,
+	}, "获取我的会议成功")
+}
+
+// parseDateParam 解析日期查询参数，endOfDay为true时日期取次日零点作为上限
+func parseDateParam(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// syncMeetingRecord 更新会议后从Zoom重新读取会议，刷新本地会议记录，非本服务创建的会议会被忽略
+func (h *ZoomHandler) syncMeetingRecord(ctx context.Context, meetingID string) {
+	zoomMeetingID, err := strconv.ParseInt(meetingID, 10, 64)
+	if err != nil {
+		return
+	}
+	record, err := h.meetingStore.GetMeetingByZoomID(zoomMeetingID)
+	if err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			logger.WithError(err).WithField("meeting_id", meetingID).Error("Failed to load meeting record")
+		}
+		return
+	}
+
+	meeting, err := h.zoomService.GetMeeting(ctx, meetingID)
+	if err != nil {
+		logger.WithError(err).WithField("meeting_id", meetingID).Warn("Failed to reload meeting after update, local record may be stale")
+		return
+	}
+	updated := models.NewMeetingRecord(meeting, record.CreatorUserID)
+	updated.CreatedAt = record.CreatedAt
+	updated.Status = record.Status
+	if err := h.meetingStore.SaveMeeting(updated); err != nil {
+		logger.WithError(err).WithField("meeting_id", meetingID).Error("Failed to update meeting record")
+	}
+}
+
+// syncMeetingStatus 同步本地会议记录状态，非本服务创建的会议会被忽略，定期会议结束后仍保持等待状态
+func (h *ZoomHandler) syncMeetingStatus(meetingID, status string) {
+	zoomMeetingID, err := strconv.ParseInt(meetingID, 10, 64)
+	if err != nil {
+		return
+	}
+	if status == models.MeetingStatusEnded {
+		err = h.meetingStore.EndMeeting(zoomMeetingID)
+	} else {
+		err = h.meetingStore.UpdateMeetingStatus(zoomMeetingID, status)
+	}
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		logger.WithError(err).WithFields(logrus.Fields{
+			"meeting_id": meetingID,
+			"status":     status,
+		}).Error("Failed to update meeting record status")
+	}
+}
+
+// validateRecurrence 校验定期会议重复规则，返回空字符串表示校验通过
+func validateRecurrence(rec *models.Recurrence) string {
+	maxInterval := 0
+	switch rec.Type {
+	case models.RecurrenceTypeDaily:
+		maxInterval = 99
+	case models.RecurrenceTypeWeekly:
+		maxInterval = 50
+		if rec.WeeklyDays == "" {
+			return "每周重复的会议必须设置 weekly_days"
+		}
+		for _, day := range strings.Split(rec.WeeklyDays, ",") {
+			d, err := strconv.Atoi(strings.TrimSpace(day))
+			if err != nil || d < 1 || d > 7 {
+				return "weekly_days 必须为 1-7 之间的数字，用逗号分隔"
+			}
+		}
+	case models.RecurrenceTypeMonthly:
+		maxInterval = 10
+		if rec.MonthlyDay != 0 && rec.MonthlyWeek != 0 {
+			return "monthly_day 与 monthly_week 不能同时设置"
+		}
+		if rec.MonthlyDay != 0 {
+			if rec.MonthlyDay < 1 || rec.MonthlyDay > 31 {
+				return "monthly_day 必须在 1-31 之间"
+			}
+		} else {
+			if rec.MonthlyWeek != -1 && (rec.MonthlyWeek < 1 || rec.MonthlyWeek > 4) {
+				return "每月重复的会议必须设置 monthly_day 或 monthly_week(-1, 1-4)"
+			}
+			if rec.MonthlyWeekDay < 1 || rec.MonthlyWeekDay > 7 {
+				return "monthly_week_day 必须在 1-7 之间"
+			}
+		}
+	default:
+		return "recurrence.type 只支持 1(每天)、2(每周)、3(每月)"
+	}
+
+	if rec.RepeatInterval < 0 || rec.RepeatInterval > maxInterval {
+		return fmt.Sprintf("repeat_interval 必须在 1-%d 之间", maxInterval)
+	}
+
+	if rec.EndTimes != 0 && rec.EndDateTime != "" {
+		return "end_times 与 end_date_time 不能同时设置"
+	}
+	if rec.EndTimes < 0 || rec.EndTimes > 60 {
+		return "end_times 必须在 1-60 之间"
+	}
+	if rec.EndDateTime != "" {
+		if _, err := time.Parse(time.RFC3339, rec.EndDateTime); err != nil {
+			return "end_date_time 必须为 ISO 8601 格式"
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,383 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"zoom-app-server/config"
+	"zoom-app-server/fakezoom"
+	"zoom-app-server/middleware"
+	"zoom-app-server/models"
+	"zoom-app-server/services"
+	"zoom-app-server/store"
+	"zoom-app-server/utils/logger"
+	"zoom-app-server/utils/response"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	testHostEmail    = "host@example.com"
+	testClientSecret = "client-secret"
+)
+
+func TestMain(m *testing.M) {
+	logger.InitLogger(&logger.LogConfig{Level: "error", Format: "text", Output: "stdout"})
+	os.Exit(m.Run())
+}
+
+// testEnv 使用 fakezoom 和临时数据库的处理器测试环境
+type testEnv struct {
+	store  *store.Store
+	router *mux.Router
+}
+
+// newTestEnv 创建测试环境，opts 用于调整配置，如模拟服务端OAuth凭据错误
+func newTestEnv(t *testing.T, opts ...func(cfg *config.Config)) *testEnv {
+	t.Helper()
+
+	_, server := fakezoom.NewTestServer(fakezoom.Config{
+		AccountID:    "account",
+		ClientID:     "client",
+		ClientSecret: testClientSecret,
+		HostEmail:    testHostEmail,
+	})
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		ZoomSDKKey:         "sdk-key",
+		ZoomSDKSecret:      "sdk-secret",
+		ZoomSignatureTTL:   7200,
+		ZoomAccountID:      "account",
+		ZoomClientID:       "client",
+		ZoomClientSecret:   testClientSecret,
+		ZoomOAuthBaseURL:   server.URL,
+		ZoomAPIBaseURL:     server.URL + "/v2",
+		ZoomHTTPTimeout:    5,
+		ZoomRequestTimeout: 10,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	meetingStore, err := store.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { meetingStore.Close() })
+
+	zoomService := services.NewZoomService(cfg)
+	t.Cleanup(func() { zoomService.Close(context.Background()) })
+
+	h := NewZoomHandler(cfg, zoomService, meetingStore)
+	router := mux.NewRouter()
+	router.HandleFunc("/api/signature", h.HandleGenerateSignature).Methods("POST")
+	router.HandleFunc("/api/meetings", h.HandleCreateMeeting).Methods("POST")
+	router.HandleFunc("/api/meetings", h.HandleListMeetings).Methods("GET")
+	router.HandleFunc("/api/meetings/{id}", h.HandleGetMeeting).Methods("GET")
+	router.HandleFunc("/api/meetings/{id}", h.HandleUpdateMeeting).Methods("PATCH")
+	router.HandleFunc("/api/meetings/{id}", h.HandleDeleteMeeting).Methods("DELETE")
+
+	return &testEnv{store: meetingStore, router: router}
+}
+
+// do 以指定用户身份发送请求，user为nil表示匿名用户
+func (e *testEnv) do(t *testing.T, method, path string, body interface{}, user *middleware.UserInfoResp) (int, models.CommonResponse, json.RawMessage) {
+	t.Helper()
+
+	var reader bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reader).Encode(body); err != nil {
+			t.Fatalf("encode body: %v", err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &reader)
+	if user != nil {
+		req = req.WithContext(middleware.WithUserInfo(req.Context(), user))
+	}
+	rec := httptest.NewRecorder()
+	e.router.ServeHTTP(rec, req)
+
+	var resp struct {
+		models.CommonResponse
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("%s %s: decode response %q: %v", method, path, rec.Body.String(), err)
+	}
+	return rec.Code, resp.CommonResponse, resp.Data
+}
+
+// createMeeting 以指定用户创建会议并返回会议号
+func (e *testEnv) createMeeting(t *testing.T, user *middleware.UserInfoResp) string {
+	t.Helper()
+
+	status, resp, data := e.do(t, "POST", "/api/meetings", models.CreateMeetingRequest{Topic: "周会", Type: models.MeetingTypeScheduled}, user)
+	if status != http.StatusOK {
+		t.Fatalf("create meeting: status %d, code %d, message %s", status, resp.Code, resp.Message)
+	}
+	var meeting models.CreateMeetingResponse
+	if err := json.Unmarshal(data, &meeting); err != nil {
+		t.Fatalf("decode meeting: %v", err)
+	}
+	return strconv.FormatInt(meeting.ID, 10)
+}
+
+// newUser 创建DooTask用户，emailVerified 表示邮箱是否已验证
+func newUser(userID int, email string, emailVerified bool, identity ...string) *middleware.UserInfoResp {
+	user := &middleware.UserInfoResp{
+		UserBasicResp: &middleware.UserBasicResp{Userid: userID, Email: email},
+		Identity:      identity,
+	}
+	if emailVerified {
+		user.EmailVerity = 1
+	}
+	return user
+}
+
+func TestMeetingLifecycle(t *testing.T) {
+	env := newTestEnv(t)
+	creator := newUser(1, "creator@example.com", true)
+
+	meetingID := env.createMeeting(t, creator)
+	zoomID, _ := strconv.ParseInt(meetingID, 10, 64)
+	record, err := env.store.GetMeetingByZoomID(zoomID)
+	if err != nil {
+		t.Fatalf("meeting record not saved: %v", err)
+	}
+	if record.CreatorUserID != creator.Userid {
+		t.Errorf("creator_user_id = %d, want %d", record.CreatorUserID, creator.Userid)
+	}
+
+	// 关闭单项设置时其他设置保持不变
+	update := map[string]interface{}{
+		"topic":    "周会（改期）",
+		"settings": map[string]interface{}{"mute_upon_entry": false},
+	}
+	if status, resp, _ := env.do(t, "PATCH", "/api/meetings/"+meetingID, update, creator); status != http.StatusOK {
+		t.Fatalf("update meeting: status %d, message %s", status, resp.Message)
+	}
+
+	status, resp, data := env.do(t, "GET", "/api/meetings/"+meetingID, nil, creator)
+	if status != http.StatusOK {
+		t.Fatalf("get meeting: status %d, message %s", status, resp.Message)
+	}
+	var meeting models.CreateMeetingResponse
+	if err := json.Unmarshal(data, &meeting); err != nil {
+		t.Fatalf("decode meeting: %v", err)
+	}
+	if meeting.Topic != "周会（改期）" {
+		t.Errorf("topic = %q, want updated topic", meeting.Topic)
+	}
+	if meeting.Settings == nil || meeting.Settings.MuteUponEntry || !meeting.Settings.HostVideo {
+		t.Errorf("settings = %+v, want mute_upon_entry off and host_video kept on", meeting.Settings)
+	}
+	if record, _ := env.store.GetMeetingByZoomID(zoomID); record == nil || record.Topic != meeting.Topic {
+		t.Errorf("local record not refreshed after update: %+v", record)
+	}
+
+	if status, resp, _ := env.do(t, "DELETE", "/api/meetings/"+meetingID, nil, creator); status != http.StatusOK {
+		t.Fatalf("delete meeting: status %d, message %s", status, resp.Message)
+	}
+	if record, _ := env.store.GetMeetingByZoomID(zoomID); record == nil || record.Status != models.MeetingStatusDeleted {
+		t.Errorf("local record not marked deleted: %+v", record)
+	}
+
+	// 删除后Zoom返回3001，映射为40402
+	status, resp, _ = env.do(t, "GET", "/api/meetings/"+meetingID, nil, creator)
+	if status != http.StatusNotFound || resp.Code != response.CodeZoomMeetingNotFound {
+		t.Errorf("get deleted meeting: status %d, code %d, want 404/%d", status, resp.Code, response.CodeZoomMeetingNotFound)
+	}
+}
+
+func TestMeetingAccessControl(t *testing.T) {
+	env := newTestEnv(t)
+	creator := newUser(1, "creator@example.com", true)
+	meetingID := env.createMeeting(t, creator)
+
+	tests := []struct {
+		name       string
+		user       *middleware.UserInfoResp
+		wantStatus int
+	}{
+		{"creator", creator, http.StatusOK},
+		{"admin", newUser(2, "admin@example.com", true, "admin"), http.StatusOK},
+		{"zoom host with verified email", newUser(3, testHostEmail, true), http.StatusOK},
+		{"zoom host email not verified", newUser(4, testHostEmail, false), http.StatusForbidden},
+		{"other user", newUser(5, "other@example.com", true), http.StatusForbidden},
+		{"anonymous", nil, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status, resp, _ := env.do(t, "GET", "/api/meetings/"+meetingID, nil, tt.user); status != tt.wantStatus {
+				t.Errorf("get meeting: status %d, want %d (message %s)", status, tt.wantStatus, resp.Message)
+			}
+		})
+	}
+
+	other := newUser(5, "other@example.com", true)
+	if status, _, _ := env.do(t, "DELETE", "/api/meetings/"+meetingID, nil, other); status != http.StatusForbidden {
+		t.Errorf("delete by other user: status %d, want 403", status)
+	}
+	if status, _, _ := env.do(t, "PATCH", "/api/meetings/"+meetingID, map[string]string{"topic": "x"}, other); status != http.StatusForbidden {
+		t.Errorf("update by other user: status %d, want 403", status)
+	}
+
+	// 会议列表只返回自己创建的会议，管理员可以看到全部
+	listCount := func(user *middleware.UserInfoResp) int {
+		status, resp, data := env.do(t, "GET", "/api/meetings", nil, user)
+		if status != http.StatusOK {
+			t.Fatalf("list meetings: status %d, message %s", status, resp.Message)
+		}
+		var list models.ListMeetingsResponse
+		if err := json.Unmarshal(data, &list); err != nil {
+			t.Fatalf("decode list: %v", err)
+		}
+		return len(list.Meetings)
+	}
+	if n := listCount(creator); n != 1 {
+		t.Errorf("creator sees %d meetings, want 1", n)
+	}
+	if n := listCount(other); n != 0 {
+		t.Errorf("other user sees %d meetings, want 0", n)
+	}
+	if n := listCount(newUser(2, "admin@example.com", true, "admin")); n != 1 {
+		t.Errorf("admin sees %d meetings, want 1", n)
+	}
+}
+
+func TestSignatureRoleDowngrade(t *testing.T) {
+	env := newTestEnv(t)
+	creator := newUser(1, "creator@example.com", true)
+	meetingID := env.createMeeting(t, creator)
+
+	tests := []struct {
+		name          string
+		user          *middleware.UserInfoResp
+		requestedRole int
+		wantRole      int
+	}{
+		{"anonymous requesting host", nil, services.SignatureRoleHost, services.SignatureRoleParticipant},
+		{"other user requesting host", newUser(5, "other@example.com", true), services.SignatureRoleHost, services.SignatureRoleParticipant},
+		{"unverified host email requesting host", newUser(4, testHostEmail, false), services.SignatureRoleHost, services.SignatureRoleParticipant},
+		{"creator requesting host", creator, services.SignatureRoleHost, services.SignatureRoleHost},
+		{"verified zoom host requesting host", newUser(3, testHostEmail, true), services.SignatureRoleHost, services.SignatureRoleHost},
+		{"admin requesting host", newUser(2, "admin@example.com", true, "admin"), services.SignatureRoleHost, services.SignatureRoleHost},
+		{"creator requesting participant", creator, services.SignatureRoleParticipant, services.SignatureRoleParticipant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := models.ZoomSignatureRequest{MeetingNumber: meetingID, Role: tt.requestedRole}
+			status, resp, data := env.do(t, "POST", "/api/signature", req, tt.user)
+			if status != http.StatusOK {
+				t.Fatalf("signature: status %d, message %s", status, resp.Message)
+			}
+			var sig models.ZoomSignatureResponse
+			if err := json.Unmarshal(data, &sig); err != nil {
+				t.Fatalf("decode signature: %v", err)
+			}
+			if sig.Role != tt.wantRole {
+				t.Errorf("role = %d, want %d", sig.Role, tt.wantRole)
+			}
+			if sig.Signature == "" {
+				t.Error("signature is empty")
+			}
+		})
+	}
+}
+
+func TestZoomErrorMapping(t *testing.T) {
+	admin := newUser(2, "admin@example.com", true, "admin")
+
+	t.Run("meeting not found", func(t *testing.T) {
+		env := newTestEnv(t)
+		status, resp, _ := env.do(t, "GET", "/api/meetings/123456789", nil, admin)
+		if status != http.StatusNotFound || resp.Code != response.CodeZoomMeetingNotFound {
+			t.Errorf("status %d, code %d, want 404/%d", status, resp.Code, response.CodeZoomMeetingNotFound)
+		}
+	})
+
+	t.Run("invalid field", func(t *testing.T) {
+		env := newTestEnv(t)
+		req := models.CreateMeetingRequest{Topic: "周会", Type: models.MeetingTypeScheduled, StartTime: "not-a-time"}
+		status, resp, _ := env.do(t, "POST", "/api/meetings", req, admin)
+		if status != http.StatusBadRequest || resp.Code != response.CodeZoomInvalidField {
+			t.Errorf("status %d, code %d, want 400/%d", status, resp.Code, response.CodeZoomInvalidField)
+		}
+	})
+
+	t.Run("server oauth credentials rejected", func(t *testing.T) {
+		env := newTestEnv(t, func(cfg *config.Config) { cfg.ZoomClientSecret = "wrong-secret" })
+		status, resp, _ := env.do(t, "GET", "/api/meetings/123456789", nil, admin)
+		if status != http.StatusBadGateway || resp.Code != response.CodeZoomAuthFailed {
+			t.Errorf("status %d, code %d, want 502/%d", status, resp.Code, response.CodeZoomAuthFailed)
+		}
+	})
+}
+
+func TestListMeetingsPaginatesOwnMeetings(t *testing.T) {
+	env := newTestEnv(t)
+	creator := newUser(1, "creator@example.com", true)
+	other := newUser(5, "other@example.com", true)
+	for i := 0; i < 3; i++ {
+		env.createMeeting(t, creator)
+	}
+	env.createMeeting(t, other)
+
+	tests := []struct {
+		query     string
+		wantCount int
+	}{
+		{"?page_size=2", 2},
+		{"?page_size=2&page_number=2", 1},
+		{"?page_size=2&page_number=3", 0},
+	}
+	for _, tt := range tests {
+		status, resp, data := env.do(t, "GET", "/api/meetings"+tt.query, nil, creator)
+		if status != http.StatusOK {
+			t.Fatalf("list %s: status %d, message %s", tt.query, status, resp.Message)
+		}
+		var list models.ListMeetingsResponse
+		if err := json.Unmarshal(data, &list); err != nil {
+			t.Fatalf("decode list: %v", err)
+		}
+		if len(list.Meetings) != tt.wantCount || list.TotalRecords != 3 || list.PageCount != 2 {
+			t.Errorf("list %s: %d meetings, total %d, pages %d; want %d meetings, total 3, pages 2",
+				tt.query, len(list.Meetings), list.TotalRecords, list.PageCount, tt.wantCount)
+		}
+	}
+
+	if status, _, _ := env.do(t, "GET", "/api/meetings?next_page_token=abc", nil, creator); status != http.StatusBadRequest {
+		t.Errorf("next_page_token for non-admin: status %d, want 400", status)
+	}
+}
+
+func TestMeetingAccessWithDooTaskAuthDisabled(t *testing.T) {
+	env := newTestEnv(t, func(cfg *config.Config) { cfg.DisableDooTaskAuth = true })
+	meetingID := env.createMeeting(t, nil)
+
+	if status, resp, _ := env.do(t, "GET", "/api/meetings/"+meetingID, nil, nil); status != http.StatusOK {
+		t.Errorf("get meeting: status %d, message %s", status, resp.Message)
+	}
+	status, resp, data := env.do(t, "GET", "/api/meetings", nil, nil)
+	if status != http.StatusOK {
+		t.Fatalf("list meetings: status %d, message %s", status, resp.Message)
+	}
+	var list models.ListMeetingsResponse
+	if err := json.Unmarshal(data, &list); err != nil {
+		t.Fatalf("decode list: %v", err)
+	}
+	if len(list.Meetings) != 1 {
+		t.Errorf("list meetings: %d meetings, want 1", len(list.Meetings))
+	}
+	if status, resp, _ := env.do(t, "DELETE", "/api/meetings/"+meetingID, nil, nil); status != http.StatusOK {
+		t.Errorf("delete meeting: status %d, message %s", status, resp.Message)
+	}
+}
@@ -1,12 +1,24 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"zoom-app-server/config"
+	"zoom-app-server/metrics"
+	"zoom-app-server/middleware"
 	"zoom-app-server/routes"
+	"zoom-app-server/store"
+	"zoom-app-server/tracing"
 	"zoom-app-server/utils/logger"
+	"zoom-app-server/utils/route"
+	"zoom-app-server/version"
 )
 
 func main() {
@@ -15,29 +27,150 @@ func main() {
 
 	// 初始化日志器
 	logConfig := &logger.LogConfig{
-		Level:    cfg.LogLevel,
-		Format:   cfg.LogFormat,
-		Output:   cfg.LogOutput,
-		FilePath: cfg.LogFilePath,
+		Level:          cfg.LogLevel,
+		Format:         cfg.LogFormat,
+		Output:         cfg.LogOutput,
+		FilePath:       cfg.LogFilePath,
+		MaxSize:        cfg.LogMaxSize,
+		MaxBackups:     cfg.LogMaxBackups,
+		MaxAge:         cfg.LogMaxAge,
+		Compress:       cfg.LogCompress,
+		RedactPatterns: cfg.LogRedactPatterns,
 	}
 	logger.InitLogger(logConfig)
+	go reopenLogOnSIGHUP()
+
+	// 打开本地存储
+	meetingStore, err := store.Open(cfg.DBPath)
+	if err != nil {
+		logger.WithError(err).Fatal("Failed to open local store")
+	}
+
+	// 初始化链路追踪
+	shutdownTracing, err := tracing.Init(context.Background(), cfg)
+	if err != nil {
+		meetingStore.Close()
+		logger.WithError(err).Fatal("Failed to initialize tracing")
+	}
+	if cfg.OTelExporterEndpoint != "" {
+		logger.WithField("endpoint", cfg.OTelExporterEndpoint).Info("OpenTelemetry tracing enabled")
+	}
 
 	// 设置路由
-	router := routes.SetupRoutes(cfg)
+	router, shutdownRoutes := routes.SetupRoutes(cfg, meetingStore)
 
-	logger.Infof("Server starting on port %s", cfg.Port)
+	buildInfo := version.Get()
+	logger.WithFields(logrus.Fields{
+		"version": buildInfo.Version,
+		"commit":  buildInfo.Commit,
+	}).Infof("Server starting on port %s", cfg.Port)
 	logger.Info("Available endpoints:")
 	logger.Info("  POST /api/signature - Generate Zoom signature (JWT)")
 	logger.Info("  POST /api/meetings - Create Zoom meeting (OAuth)")
+	logger.Info("  GET /api/meetings - List Zoom meetings")
+	logger.Info("  GET /api/meetings/{id} - Get Zoom meeting")
+	logger.Info("  PATCH /api/meetings/{id} - Update Zoom meeting")
+	logger.Info("  DELETE /api/meetings/{id} - Delete Zoom meeting")
+	logger.Info("  PUT /api/meetings/{id}/status - End Zoom meeting")
+	logger.Info("  GET /api/meetings/{id}/occurrences - List recurring meeting occurrences")
+	logger.Info("  PATCH /api/meetings/{id}/occurrences/{occurrence_id} - Update meeting occurrence")
+	logger.Info("  DELETE /api/meetings/{id}/occurrences/{occurrence_id} - Delete meeting occurrence")
+	logger.Info("  GET /api/me/meetings - List meetings of the current DooTask user")
+	logger.Info("  POST /api/video-sdk/token - Generate Zoom Video SDK session token")
+	logger.Info("  GET /api/tokens/zak - Get ZAK token of the current user")
+	logger.Info("  GET /api/tokens/obf - Get OBF token of the current user")
+	logger.Info("  POST /api/webhooks/zoom - Receive Zoom webhook events")
 	logger.Info("  GET /api/config - Get server configuration")
-	
+	logger.Info("  GET|PUT /api/admin/log-level - View or change log level (admin)")
+	logger.Info("  GET /api/admin/config - View sanitized configuration (admin)")
+	logger.Info("  GET /api/admin/version - View build info (admin)")
+	logger.Info("  GET|PUT /api/admin/pprof - View or toggle pprof at /api/admin/debug/pprof/ (admin)")
+	logger.Info("  GET /healthz - Liveness check")
+	logger.Info("  GET /readyz - Readiness check")
+	logger.Info("  GET /metrics - Prometheus metrics")
+
 	logger.WithFields(logrus.Fields{
-		"port": cfg.Port,
-		"log_level": cfg.LogLevel,
+		"port":       cfg.Port,
+		"log_level":  cfg.LogLevel,
 		"log_format": cfg.LogFormat,
 	}).Info("Server configuration loaded")
-	
-	if err := http.ListenAndServe(":"+cfg.Port, router); err != nil {
-		logger.WithError(err).Fatal("Failed to start server")
+
+	server := &http.Server{
+		Addr:              ":" + cfg.Port,
+		Handler:           tracing.Handler(route.Handler(metrics.Middleware(middleware.RequestLogger(router)))),
+		ReadTimeout:       time.Duration(cfg.ServerReadTimeout) * time.Second,
+		ReadHeaderTimeout: time.Duration(cfg.ServerReadHeaderTimeout) * time.Second,
+		WriteTimeout:      time.Duration(cfg.ServerWriteTimeout) * time.Second,
+		IdleTimeout:       time.Duration(cfg.ServerIdleTimeout) * time.Second,
+		MaxHeaderBytes:    cfg.ServerMaxHeaderBytes,
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+
+	// 等待退出信号
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGTERM, syscall.SIGINT)
+	select {
+	case err := <-serverErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			meetingStore.Close()
+			logger.WithError(err).Fatal("Failed to start server")
+		}
+	case sig := <-quit:
+		logger.WithFields(logrus.Fields{
+			"signal":  sig.String(),
+			"timeout": cfg.ShutdownTimeout,
+		}).Info("Shutdown signal received, draining in-flight requests")
+	}
+	signal.Stop(quit)
+
+	shutdown(server, shutdownRoutes, shutdownTracing, meetingStore, time.Duration(cfg.ShutdownTimeout)*time.Second)
+}
+
+// reopenLogOnSIGHUP 收到SIGHUP时重新打开日志文件，配合外部logrotate使用
+func reopenLogOnSIGHUP() {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	for range hup {
+		if err := logger.Reopen(); err != nil {
+			logger.WithError(err).Error("Failed to reopen log file")
+			continue
+		}
+		logger.Info("Log file reopened")
 	}
 }
+
+// shutdown 按顺序关闭：停止接收请求并等待进行中的请求，再等待后台任务，然后导出剩余的追踪数据，最后关闭存储
+func shutdown(server *http.Server, shutdownRoutes, shutdownTracing func(ctx context.Context) error, meetingStore *store.Store, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := time.Now()
+	if err := server.Shutdown(ctx); err != nil {
+		logger.WithError(err).Warn("Grace period expired, closing remaining connections")
+		server.Close()
+	} else {
+		logger.WithField("elapsed", time.Since(start).String()).Info("HTTP server stopped")
+	}
+
+	if err := shutdownRoutes(ctx); err != nil {
+		logger.WithError(err).Warn("Background tasks did not finish before shutdown timeout")
+	} else {
+		logger.Info("Background tasks stopped")
+	}
+
+	if err := shutdownTracing(ctx); err != nil {
+		logger.WithError(err).Warn("Failed to flush traces")
+	}
+
+	if err := meetingStore.Close(); err != nil {
+		logger.WithError(err).Error("Failed to close local store")
+	} else {
+		logger.Info("Local store closed")
+	}
+
+	logger.WithField("elapsed", time.Since(start).String()).Info("Server shutdown complete")
+}
@@ -0,0 +1,161 @@
+// Package metrics 定义 Prometheus 指标，通过 /metrics 暴露
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"zoom-app-server/utils/route"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "zoom_app"
+
+var (
+	// HTTPRequestsTotal 按路由模板、方法、状态码统计的请求数
+	HTTPRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests handled, by route template, method and status.",
+	}, []string{"route", "method", "status"})
+
+	// HTTPRequestDuration 按路由模板、方法统计的请求耗时
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency, by route template and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	// ZoomRequestsTotal Zoom 上游调用次数（每次重试单独计数），status 为状态码或 error
+	ZoomRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "zoom_requests_total",
+		Help:      "Upstream Zoom API calls including retries, by endpoint, method and status.",
+	}, []string{"endpoint", "method", "status"})
+
+	// ZoomRequestDuration Zoom 上游调用耗时
+	ZoomRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "zoom_request_duration_seconds",
+		Help:      "Upstream Zoom API call latency, by endpoint and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"endpoint", "method"})
+
+	// ZoomOAuthTokenFetches 向 Zoom 获取 OAuth 令牌的次数，result 为 success 或 failure
+	ZoomOAuthTokenFetches = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "zoom_oauth_token_fetches_total",
+		Help:      "OAuth token fetches from Zoom, by result.",
+	}, []string{"result"})
+
+	// ZoomOAuthTokenCache OAuth 令牌缓存命中情况，result 为 hit 或 miss
+	ZoomOAuthTokenCache = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "zoom_oauth_token_cache_total",
+		Help:      "OAuth token lookups served from cache (hit) or waiting for a fetch (miss).",
+	}, []string{"result"})
+
+	// DooTaskValidationDuration DooTask token 验证耗时，result 为 success、rejected 或 error
+	DooTaskValidationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "dootask_validation_duration_seconds",
+		Help:      "DooTask token validation latency against /api/users/info, by result.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"result"})
+
+	// DooTaskValidationFailures DooTask token 验证失败次数，reason 为 rejected 或 error
+	DooTaskValidationFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "dootask_validation_failures_total",
+		Help:      "DooTask token validations that failed, by reason.",
+	}, []string{"reason"})
+
+	// SignaturesIssued 签发的 Meeting SDK 签名数，按请求角色与实际角色统计，角色为 0、1 或 other
+	SignaturesIssued = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "signatures_issued_total",
+		Help:      "Meeting SDK signatures issued, by requested and granted role.",
+	}, []string{"requested_role", "role"})
+
+	// MeetingsCreated 创建成功的会议数，按会议类型统计
+	MeetingsCreated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "meetings_created_total",
+		Help:      "Zoom meetings created, by meeting type.",
+	}, []string{"type"})
+)
+
+// RoleLabel 将签名角色转换为指标标签，客户端传入的未知角色统一为 other，避免标签基数无限增长
+func RoleLabel(role int) string {
+	switch role {
+	case 0, 1:
+		return strconv.Itoa(role)
+	default:
+		return "other"
+	}
+}
+
+// Handler 返回 /metrics 处理器
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Middleware 记录每个请求的数量和耗时，路由使用 mux 的路由模板避免标签基数过高
+// 需包裹整个路由器而不是通过 router.Use 注册，路由模板由 route.Handler 放入上下文，404/405 记为 unmatched
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		tpl := route.Template(r.Context())
+		HTTPRequestsTotal.WithLabelValues(tpl, r.Method, strconv.Itoa(recorder.status)).Inc()
+		HTTPRequestDuration.WithLabelValues(tpl, r.Method).Observe(time.Since(start).Seconds())
+	})
+}
+
+// statusRecorder 记录响应状态码
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader 记录状态码
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// ObserveZoomRequest 记录一次 Zoom 上游调用，status 为0表示请求未得到响应
+func ObserveZoomRequest(method, path string, status int, duration time.Duration) {
+	endpoint := ZoomEndpoint(path)
+	statusLabel := "error"
+	if status > 0 {
+		statusLabel = strconv.Itoa(status)
+	}
+	ZoomRequestsTotal.WithLabelValues(endpoint, method, statusLabel).Inc()
+	ZoomRequestDuration.WithLabelValues(endpoint, method).Observe(duration.Seconds())
+}
+
+// ZoomEndpoint 将 Zoom 请求路径中的用户和会议ID替换为占位符，如 /v2/meetings/123/status -> /meetings/{meetingId}/status
+func ZoomEndpoint(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 0 && segments[0] == "v2" {
+		segments = segments[1:]
+	}
+	for i := 1; i < len(segments); i++ {
+		switch segments[i-1] {
+		case "users":
+			segments[i] = "{userId}"
+		case "meetings":
+			segments[i] = "{meetingId}"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
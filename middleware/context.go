@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+)
+
+// contextKey 请求上下文键类型，避免与其他包冲突
+type contextKey int
+
+const (
+	userInfoContextKey contextKey = iota
+)
+
+// identityHeaders 客户端不能自行设置的身份请求头
+var identityHeaders = []string{
+	"X-User-ID",
+	"X-User-Email",
+	"X-Username",
+	"X-Nickname",
+	"X-Email",
+}
+
+// WithUserInfo 将已认证的用户信息写入上下文
+func WithUserInfo(ctx context.Context, userInfo *UserInfoResp) context.Context {
+	return context.WithValue(ctx, userInfoContextKey, userInfo)
+}
+
+// UserInfoFromContext 从上下文获取已认证的用户信息
+func UserInfoFromContext(ctx context.Context) (*UserInfoResp, bool) {
+	userInfo, ok := ctx.Value(userInfoContextKey).(*UserInfoResp)
+	if !ok || userInfo == nil || userInfo.UserBasicResp == nil {
+		return nil, false
+	}
+	return userInfo, true
+}
+
+// UserIDFromContext 获取已认证用户的ID，未认证时返回0
+func UserIDFromContext(ctx context.Context) int {
+	if userInfo, ok := UserInfoFromContext(ctx); ok {
+		return userInfo.Userid
+	}
+	return 0
+}
+
+// UserEmailFromContext 获取已认证用户的邮箱，未认证时返回空字符串
+func UserEmailFromContext(ctx context.Context) string {
+	if userInfo, ok := UserInfoFromContext(ctx); ok {
+		return userInfo.Email
+	}
+	return ""
+}
+
+// IsAdminFromContext 已认证用户是否为DooTask管理员
+func IsAdminFromContext(ctx context.Context) bool {
+	if userInfo, ok := UserInfoFromContext(ctx); ok {
+		return userInfo.IsAdmin()
+	}
+	return false
+}
+
+// stripIdentityHeaders 删除客户端传入的身份请求头
+func stripIdentityHeaders(r *http.Request) {
+	for _, header := range identityHeaders {
+		r.Header.Del(header)
+	}
+}
@@ -1,19 +1,22 @@
 package middleware
 
 import (
+	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
-	"fmt"
-	"io"
 	"net/http"
 	"strings"
 	"time"
 
 	"zoom-app-server/config"
-	"zoom-app-server/utils/common"
+	"zoom-app-server/metrics"
+	"zoom-app-server/models"
+	"zoom-app-server/tracing"
 	"zoom-app-server/utils/logger"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // DooTaskAuthResponse DooTask验证响应结构
@@ -74,30 +77,161 @@ func (u *UserInfoResp) IsAdmin() bool {
 	return false
 }
 
+// VerifiedEmail 返回已验证的邮箱，未验证时返回空字符串
+// 用户可以随意修改未验证的邮箱，不能用它匹配Zoom账号或会议主持人
+func (u *UserInfoResp) VerifiedEmail() string {
+	if u.EmailVerity != 1 || u.UserBasicResp == nil {
+		return ""
+	}
+	return u.Email
+}
+
+// ErrDooTaskRequestFailed DooTask明确拒绝了请求（如token无效）
+var ErrDooTaskRequestFailed = errors.New("ErrDooTaskRequestFailed")
+
+// DooTaskClient DooTask用户服务接口，便于在测试中替换为假实现
+type DooTaskClient interface {
+	// GetUserInfo 通过token获取用户信息，token无效时返回包装了ErrDooTaskRequestFailed的错误
+	GetUserInfo(ctx context.Context, token string) (*UserInfoResp, error)
+}
+
 // DooTaskMiddleware DooTask验证中间件
 type DooTaskMiddleware struct {
-	cfg *config.Config
+	cfg    *config.Config
+	client DooTaskClient
+	cache  *tokenCache
 }
 
 // NewDooTaskMiddleware 创建新的DooTask中间件实例
 func NewDooTaskMiddleware(cfg *config.Config) *DooTaskMiddleware {
+	return NewDooTaskMiddlewareWithClient(cfg, NewDooTaskHTTPClient(cfg))
+}
+
+// NewDooTaskMiddlewareWithClient 使用指定的DooTask客户端创建中间件实例
+func NewDooTaskMiddlewareWithClient(cfg *config.Config, client DooTaskClient) *DooTaskMiddleware {
 	return &DooTaskMiddleware{
-		cfg: cfg,
+		cfg:    cfg,
+		client: client,
+		cache: newTokenCache(
+			time.Duration(cfg.DooTaskCacheTTL)*time.Second,
+			time.Duration(cfg.DooTaskCacheNegativeTTL)*time.Second,
+			cfg.DooTaskCacheSize,
+		),
+	}
+}
+
+// Close 等待进行中的token验证结束，用于服务退出
+func (m *DooTaskMiddleware) Close(ctx context.Context) error {
+	return m.cache.Wait(ctx)
+}
+
+// AuthPolicy 路由认证策略
+type AuthPolicy int
+
+const (
+	// AuthRequired 必须携带有效token
+	AuthRequired AuthPolicy = iota
+	// AuthOptional 有token时验证并附加用户信息，无token时匿名访问，token无效时拒绝
+	AuthOptional
+	// AuthNone 不做DooTask认证
+	AuthNone
+	// AuthAdmin 仅管理员：携带有效的管理接口令牌，或token对应的DooTask用户是管理员
+	AuthAdmin
+)
+
+// String 返回策略名称
+func (p AuthPolicy) String() string {
+	switch p {
+	case AuthRequired:
+		return "required"
+	case AuthOptional:
+		return "optional"
+	case AuthNone:
+		return "none"
+	case AuthAdmin:
+		return "admin"
+	default:
+		return "unknown"
+	}
+}
+
+// WithPolicy 根据认证策略返回对应的中间件
+func (m *DooTaskMiddleware) WithPolicy(policy AuthPolicy) func(http.Handler) http.Handler {
+	switch policy {
+	case AuthOptional:
+		return m.OptionalAuthMiddleware
+	case AuthNone:
+		return m.NoAuthMiddleware
+	case AuthAdmin:
+		return m.AdminAuthMiddleware
+	default:
+		return m.AuthMiddleware
 	}
 }
 
 // AuthMiddleware DooTask认证中间件
 func (m *DooTaskMiddleware) AuthMiddleware(next http.Handler) http.Handler {
+	return m.authHandler(next, AuthRequired)
+}
+
+// OptionalAuthMiddleware DooTask可选认证中间件
+func (m *DooTaskMiddleware) OptionalAuthMiddleware(next http.Handler) http.Handler {
+	return m.authHandler(next, AuthOptional)
+}
+
+// NoAuthMiddleware 不做认证，仅清理客户端传入的身份请求头
+func (m *DooTaskMiddleware) NoAuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stripIdentityHeaders(r)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// AdminAuthMiddleware 管理接口认证中间件，管理接口令牌有效时不再做DooTask认证
+func (m *DooTaskMiddleware) AdminAuthMiddleware(next http.Handler) http.Handler {
+	requireAdmin := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userInfo, ok := UserInfoFromContext(r.Context())
+		if !ok || !userInfo.IsAdmin() {
+			logger.FromContext(r.Context()).Warn("Non-admin request to admin endpoint denied")
+			m.respondWithError(w, "Admin permission required", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+	userAuth := m.authHandler(requireAdmin, AuthRequired)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if m.validAdminToken(r) {
+			stripIdentityHeaders(r)
+			logger.AddFields(r.Context(), logrus.Fields{"auth": "admin_token"})
+			next.ServeHTTP(w, r)
+			return
+		}
+		userAuth.ServeHTTP(w, r)
+	})
+}
+
+// validAdminToken 检查请求是否携带有效的管理接口令牌，未配置令牌时始终无效
+func (m *DooTaskMiddleware) validAdminToken(r *http.Request) bool {
+	token := r.Header.Get(models.AdminTokenHeader)
+	if m.cfg.AdminToken == "" || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(m.cfg.AdminToken)) == 1
+}
+
+// authHandler 按认证策略处理请求
+func (m *DooTaskMiddleware) authHandler(next http.Handler, policy AuthPolicy) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		logger.WithFields(logrus.Fields{
-			"method": r.Method,
-			"path":   r.URL.Path,
-			"remote": r.RemoteAddr,
-		}).Debug("Processing DooTask auth middleware")
+		log := logger.FromContext(r.Context())
+		log.WithField("policy", policy.String()).Debug("Processing DooTask auth middleware")
+
+		// 身份信息只能来自token验证结果，不信任客户端传入的请求头
+		stripIdentityHeaders(r)
 
 		// 如果禁用了DooTask验证，直接通过
 		if m.cfg.DisableDooTaskAuth {
-			logger.Debug("DooTask auth disabled, skipping validation")
+			log.Debug("DooTask auth disabled, skipping validation")
 			next.ServeHTTP(w, r)
 			return
 		}
@@ -105,33 +239,41 @@ func (m *DooTaskMiddleware) AuthMiddleware(next http.Handler) http.Handler {
 		// 从Header或查询参数获取token
 		token := m.extractToken(r)
 		if token == "" {
+			if policy == AuthOptional {
+				log.Debug("No DooTask token provided, continuing anonymously")
+				next.ServeHTTP(w, r)
+				return
+			}
 			m.respondWithError(w, "Token is required", http.StatusUnauthorized)
 			return
 		}
 
 		// 验证token
-		logger.WithField("token_length", len(token)).Debug("Validating DooTask token")
-		userInfo, err := m.validateToken(token)
+		log.WithField("token_length", len(token)).Debug("Validating DooTask token")
+		userInfo, err := m.cache.Get(r.Context(), token, func(ctx context.Context) (*UserInfoResp, error) {
+			return m.validateToken(ctx, token)
+		})
+		if err != nil && r.Context().Err() != nil {
+			// 客户端已断开，无需再响应
+			log.WithError(err).Debug("Request canceled during DooTask token validation")
+			return
+		}
 		if err != nil {
-			logger.WithError(err).Error("DooTask token validation failed", err.Error())
+			log.WithError(err).Error("DooTask token validation failed")
 			m.respondWithError(w, "Invalid token", http.StatusUnauthorized)
 			return
 		}
 
-		logger.WithFields(logrus.Fields{
-			"user_id":  userInfo.Userid,
+		// 后续日志（包括访问日志）都带上用户ID
+		logger.AddFields(r.Context(), logrus.Fields{"user_id": userInfo.Userid})
+		logger.FromContext(r.Context()).WithFields(logrus.Fields{
 			"nickname": userInfo.Nickname,
 			"email":    userInfo.Email,
 		}).Info("DooTask token validation successful")
+		tracing.SetUserID(r.Context(), userInfo.Userid)
 
-		// // 将用户信息添加到请求上下文中
-		r.Header.Set("X-User-ID", fmt.Sprintf("%d", userInfo.Userid))
-		// r.Header.Set("X-Username", userInfo.Data.Username)
-		// r.Header.Set("X-Nickname", userInfo.Data.Nickname)
-		// r.Header.Set("X-Email", userInfo.Data.Email)
-
-		// 继续处理请求
-		next.ServeHTTP(w, r)
+		// 将用户信息添加到请求上下文中
+		next.ServeHTTP(w, r.WithContext(WithUserInfo(r.Context(), userInfo)))
 	})
 }
 
@@ -153,42 +295,29 @@ func (m *DooTaskMiddleware) extractToken(r *http.Request) string {
 	return r.URL.Query().Get("token")
 }
 
-// validateToken 验证token
-func (m *DooTaskMiddleware) validateToken(token string) (*UserInfoResp, error) {
-	// 构建验证URL
-	validateURL := fmt.Sprintf("%s%s?token=%s", m.cfg.DooTaskURL, "/api/users/info", token)
-
-	logger.WithFields(logrus.Fields{
-		"dootask_url": m.cfg.DooTaskURL,
-		"timeout":     m.cfg.DooTaskTimeout,
-	}).Info("Sending token validation request to DooTask")
-
-	// 创建HTTP客户端
-	client := &http.Client{
-		Timeout: time.Duration(m.cfg.DooTaskTimeout) * time.Second,
+// validateToken 验证token，并记录验证耗时和失败原因
+func (m *DooTaskMiddleware) validateToken(ctx context.Context, token string) (*UserInfoResp, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "DooTask validate token")
+	start := time.Now()
+	userInfo, err := m.client.GetUserInfo(ctx, token)
+	if err == nil {
+		tracing.SetUserID(ctx, userInfo.Userid)
 	}
 
-	// 发送验证请求
-	resp, err := client.Get(validateURL)
-	if err != nil {
-		logger.WithError(err).WithField("dootask_url", m.cfg.DooTaskURL).Error("Failed to send validation request to DooTask")
-		return nil, fmt.Errorf("failed to validate token: %w", err)
-	}
-	defer resp.Body.Close()
-	result, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	result := "success"
+	switch {
+	case errors.Is(err, ErrDooTaskRequestFailed):
+		result = "rejected"
+	case err != nil:
+		result = "error"
 	}
-	info, err := m.UnmarshalAndCheckResponse(result)
+	metrics.DooTaskValidationDuration.WithLabelValues(result).Observe(time.Since(start).Seconds())
 	if err != nil {
-		return nil, err
-	}
-	userInfo := new(UserInfoResp)
-	if err := common.MapToStruct(info, userInfo); err != nil {
-		return nil, err
+		metrics.DooTaskValidationFailures.WithLabelValues(result).Inc()
 	}
-
-	return userInfo, nil
+	span.SetAttributes(attribute.String("dootask.result", result))
+	tracing.EndSpan(span, err)
+	return userInfo, err
 }
 
 // respondWithError 返回错误响应
@@ -201,43 +330,9 @@ func (m *DooTaskMiddleware) respondWithError(w http.ResponseWriter, message stri
 		"message": message,
 		"code":    statusCode,
 	}
-
-	json.NewEncoder(w).Encode(errorResp)
-}
-
-// 解码并检查返回数据
-func (d *DooTaskMiddleware) UnmarshalAndCheckResponse(resp []byte) (map[string]interface{}, error) {
-	logger.Info("Resp", string(resp))
-	var ret map[string]interface{}
-	if err := json.Unmarshal(resp, &ret); err != nil {
-		// return nil, e.NewErrorWithDetail(constant.ErrDooTaskUnmarshalResponse, err, nil)
-		return nil, errors.New("ErrDooTaskUnmarshalResponse")
-	}
-
-	retCode, ok := ret["ret"].(float64)
-	if !ok {
-		return nil, errors.New("ErrDooTaskResponseFormat")
+	if requestID := w.Header().Get(models.RequestIDHeader); requestID != "" {
+		errorResp["request_id"] = requestID
 	}
 
-	if retCode != 1 {
-		msg, ok := ret["msg"].(string)
-		if !ok {
-			return nil, errors.New("ErrDooTaskRequestFailed")
-		}
-		// return nil, e.NewErrorWithDetail("ErrDooTaskRequestFailedWithErr, msg, nil)
-		return nil, errors.New("ErrDooTaskRequestFailedWithErr " + msg)
-	}
-
-	data, ok := ret["data"].(map[string]interface{})
-	if !ok {
-		dataList, isList := ret["data"].([]interface{})
-		if !isList {
-			return nil, errors.New("ErrDooTaskDataFormat")
-		}
-
-		data = make(map[string]interface{})
-		data["list"] = dataList
-	}
-
-	return data, nil
+	json.NewEncoder(w).Encode(errorResp)
 }
@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"zoom-app-server/config"
+	"zoom-app-server/tracing"
+	"zoom-app-server/utils/common"
+	"zoom-app-server/utils/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DooTaskHTTPClient 通过HTTP调用DooTask接口的客户端
+type DooTaskHTTPClient struct {
+	cfg     *config.Config
+	client  *http.Client
+	timeout time.Duration
+}
+
+var _ DooTaskClient = (*DooTaskHTTPClient)(nil)
+
+// NewDooTaskHTTPClient 创建DooTask HTTP客户端，复用连接池
+func NewDooTaskHTTPClient(cfg *config.Config) *DooTaskHTTPClient {
+	return &DooTaskHTTPClient{
+		cfg:     cfg,
+		client:  &http.Client{Transport: tracing.Transport(http.DefaultTransport)},
+		timeout: time.Duration(cfg.DooTaskTimeout) * time.Second,
+	}
+}
+
+// Ping 检查DooTask是否可达，只要 /api/users/info 返回了HTTP响应即视为可达
+func (c *DooTaskHTTPClient) Ping(ctx context.Context) error {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", strings.TrimRight(c.cfg.DooTaskURL, "/")+"/api/users/info", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("dootask returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// GetUserInfo 调用 /api/users/info 获取token对应的用户信息
+// 每次调用受 DOOTASK_TIMEOUT 限制，ctx 取消时立即返回
+func (c *DooTaskHTTPClient) GetUserInfo(ctx context.Context, token string) (*UserInfoResp, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	// 构建验证URL，token 通过请求头传递，避免出现在链路追踪的 url.full 和错误信息中
+	validateURL := strings.TrimRight(c.cfg.DooTaskURL, "/") + "/api/users/info"
+
+	logger.WithFields(logrus.Fields{
+		"dootask_url": c.cfg.DooTaskURL,
+		"timeout":     c.cfg.DooTaskTimeout,
+	}).Info("Sending token validation request to DooTask")
+
+	req, err := http.NewRequestWithContext(ctx, "GET", validateURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("token", token)
+
+	// 发送验证请求
+	resp, err := c.client.Do(req)
+	if err != nil {
+		logger.WithError(err).WithField("dootask_url", c.cfg.DooTaskURL).Error("Failed to send validation request to DooTask")
+		return nil, fmt.Errorf("failed to validate token: %w", err)
+	}
+	defer resp.Body.Close()
+	result, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	info, err := UnmarshalAndCheckResponse(result)
+	if err != nil {
+		return nil, err
+	}
+	userInfo := &UserInfoResp{UserBasicResp: &UserBasicResp{}}
+	if err := common.MapToStruct(info, userInfo); err != nil {
+		return nil, err
+	}
+
+	return userInfo, nil
+}
+
+// 解码并检查返回数据
+func UnmarshalAndCheckResponse(resp []byte) (map[string]interface{}, error) {
+	// 响应中包含用户token、手机号和IP，只记录长度
+	logger.WithField("bytes", len(resp)).Debug("DooTask user info response received")
+	var ret map[string]interface{}
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		// return nil, e.NewErrorWithDetail(constant.ErrDooTaskUnmarshalResponse, err, nil)
+		return nil, errors.New("ErrDooTaskUnmarshalResponse")
+	}
+
+	retCode, ok := ret["ret"].(float64)
+	if !ok {
+		return nil, errors.New("ErrDooTaskResponseFormat")
+	}
+
+	if retCode != 1 {
+		msg, ok := ret["msg"].(string)
+		if !ok {
+			return nil, ErrDooTaskRequestFailed
+		}
+		// return nil, e.NewErrorWithDetail("ErrDooTaskRequestFailedWithErr, msg, nil)
+		return nil, fmt.Errorf("%w: %s", ErrDooTaskRequestFailed, msg)
+	}
+
+	data, ok := ret["data"].(map[string]interface{})
+	if !ok {
+		dataList, isList := ret["data"].([]interface{})
+		if !isList {
+			return nil, errors.New("ErrDooTaskDataFormat")
+		}
+
+		data = make(map[string]interface{})
+		data["list"] = dataList
+	}
+
+	return data, nil
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"zoom-app-server/config"
+	"zoom-app-server/utils/logger"
+)
+
+func TestMain(m *testing.M) {
+	logger.InitLogger(&logger.LogConfig{Level: "error", Format: "text", Output: "stdout"})
+	os.Exit(m.Run())
+}
+
+func TestGetUserInfoSendsTokenInHeader(t *testing.T) {
+	var gotQuery, gotToken string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		gotToken = r.Header.Get("token")
+		w.Write([]byte(`{"ret":1,"msg":"","data":{"userid":7,"email":"user@example.com","email_verity":1}}`))
+	}))
+	defer server.Close()
+
+	client := NewDooTaskHTTPClient(&config.Config{DooTaskURL: server.URL, DooTaskTimeout: 5})
+	userInfo, err := client.GetUserInfo(context.Background(), "secret-token")
+	if err != nil {
+		t.Fatalf("GetUserInfo: %v", err)
+	}
+	if gotToken != "secret-token" {
+		t.Errorf("token header = %q, want secret-token", gotToken)
+	}
+	if gotQuery != "" {
+		t.Errorf("query = %q, token must not be sent in the URL", gotQuery)
+	}
+	if userInfo.Userid != 7 || userInfo.VerifiedEmail() != "user@example.com" {
+		t.Errorf("userInfo = %+v", userInfo.UserBasicResp)
+	}
+}
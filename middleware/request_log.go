@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"zoom-app-server/models"
+	"zoom-app-server/utils/logger"
+	"zoom-app-server/utils/route"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxRequestIDLength 接受的客户端请求ID最大长度
+const maxRequestIDLength = 128
+
+// quietPaths 探针和指标接口的访问日志只在debug级别输出
+var quietPaths = map[string]bool{
+	"/metrics": true,
+	"/healthz": true,
+	"/readyz":  true,
+}
+
+// RequestLogger 为每个请求分配请求ID、创建请求级日志条目，并在请求结束后输出一行访问日志
+// 路由模板由 route.Handler 放入上下文，未匹配的请求同样会记录
+func RequestLogger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		requestID := r.Header.Get(models.RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = newRequestID()
+		}
+		r.Header.Set(models.RequestIDHeader, requestID)
+		w.Header().Set(models.RequestIDHeader, requestID)
+
+		fields := logrus.Fields{"request_id": requestID}
+		if spanContext := trace.SpanContextFromContext(r.Context()); spanContext.HasTraceID() {
+			fields["trace_id"] = spanContext.TraceID().String()
+		}
+		ctx := logger.NewContext(r.Context(), logger.WithFields(fields))
+		r = r.WithContext(ctx)
+
+		recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		entry := logger.FromContext(ctx).WithFields(logrus.Fields{
+			"method":      r.Method,
+			"path":        r.URL.Path,
+			"route":       route.Template(ctx),
+			"status":      recorder.status,
+			"bytes":       recorder.bytes,
+			"duration_ms": float64(time.Since(start).Microseconds()) / 1000,
+			"remote":      r.RemoteAddr,
+			"user_agent":  r.UserAgent(),
+		})
+		switch {
+		case quietPaths[r.URL.Path]:
+			entry.Debug("Request completed")
+		case recorder.status >= http.StatusInternalServerError:
+			entry.Error("Request completed")
+		default:
+			entry.Info("Request completed")
+		}
+	})
+}
+
+// responseRecorder 记录响应状态码和字节数
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+// WriteHeader 记录状态码
+func (r *responseRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write 记录写入的字节数
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+// validRequestID 只接受长度合理、由可见安全字符组成的请求ID，避免日志注入
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID 生成随机请求ID
+func newRequestID() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// tokenCacheEntry 缓存的验证结果
+type tokenCacheEntry struct {
+	key       string
+	userInfo  *UserInfoResp
+	err       error
+	expiresAt time.Time
+}
+
+// tokenLookup 正在进行中的验证请求
+type tokenLookup struct {
+	done     chan struct{}
+	userInfo *UserInfoResp
+	err      error
+}
+
+// tokenCache DooTask token验证结果缓存
+// 以token的哈希为键，有效token按ttl缓存，无效token按negativeTTL缓存，超出容量时淘汰最久未使用的记录
+type tokenCache struct {
+	ttl         time.Duration
+	negativeTTL time.Duration
+	maxEntries  int
+
+	mu       sync.Mutex
+	entries  map[string]*list.Element
+	lru      *list.List
+	inflight map[string]*tokenLookup
+	loads    sync.WaitGroup
+}
+
+// newTokenCache 创建token缓存
+func newTokenCache(ttl, negativeTTL time.Duration, maxEntries int) *tokenCache {
+	return &tokenCache{
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		maxEntries:  maxEntries,
+		entries:     make(map[string]*list.Element),
+		lru:         list.New(),
+		inflight:    make(map[string]*tokenLookup),
+	}
+}
+
+// Get 获取token对应的用户信息，未命中时调用load，同一token的并发请求只会调用一次load
+// load 由所有等待者共享，使用脱离取消的ctx执行；ctx取消只会让当前调用方提前返回
+func (c *tokenCache) Get(ctx context.Context, token string, load func(ctx context.Context) (*UserInfoResp, error)) (*UserInfoResp, error) {
+	key := hashToken(token)
+
+	c.mu.Lock()
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*tokenCacheEntry)
+		if time.Now().Before(entry.expiresAt) {
+			c.lru.MoveToFront(elem)
+			c.mu.Unlock()
+			return entry.userInfo, entry.err
+		}
+		c.removeLocked(elem)
+	}
+	lookup, ok := c.inflight[key]
+	if !ok {
+		lookup = &tokenLookup{done: make(chan struct{})}
+		c.inflight[key] = lookup
+		c.loads.Add(1)
+		go c.runLoad(context.WithoutCancel(ctx), key, lookup, load)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-lookup.done:
+		return lookup.userInfo, lookup.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// runLoad 执行验证并唤醒所有等待者
+func (c *tokenCache) runLoad(ctx context.Context, key string, lookup *tokenLookup, load func(ctx context.Context) (*UserInfoResp, error)) {
+	defer c.loads.Done()
+	lookup.userInfo, lookup.err = load(ctx)
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	c.storeLocked(key, lookup.userInfo, lookup.err)
+	c.mu.Unlock()
+	close(lookup.done)
+}
+
+// Wait 等待进行中的验证结束，ctx到期时提前返回
+func (c *tokenCache) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		c.loads.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// storeLocked 写入验证结果，调用方需持有锁
+// 只有DooTask明确拒绝的token才做负缓存，网络错误等临时故障不缓存
+func (c *tokenCache) storeLocked(key string, userInfo *UserInfoResp, err error) {
+	ttl := c.ttl
+	if err != nil {
+		if !errors.Is(err, ErrDooTaskRequestFailed) {
+			return
+		}
+		ttl = c.negativeTTL
+	}
+	if ttl <= 0 || c.maxEntries <= 0 {
+		return
+	}
+
+	entry := &tokenCacheEntry{
+		key:       key,
+		userInfo:  userInfo,
+		err:       err,
+		expiresAt: time.Now().Add(ttl),
+	}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.maxEntries {
+		c.removeLocked(c.lru.Back())
+	}
+}
+
+// removeLocked 删除缓存记录，调用方需持有锁
+func (c *tokenCache) removeLocked(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*tokenCacheEntry).key)
+}
+
+// hashToken 计算token的SHA-256，避免在内存中以明文作为键
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingLoader 记录调用次数的token验证函数
+type countingLoader struct {
+	calls atomic.Int32
+}
+
+func (l *countingLoader) load(err error) func(ctx context.Context) (*UserInfoResp, error) {
+	return func(ctx context.Context) (*UserInfoResp, error) {
+		l.calls.Add(1)
+		if err != nil {
+			return nil, err
+		}
+		return &UserInfoResp{UserBasicResp: &UserBasicResp{Userid: 1}}, nil
+	}
+}
+
+func TestTokenCacheGet(t *testing.T) {
+	errRejected := fmt.Errorf("%w: token expired", ErrDooTaskRequestFailed)
+	errNetwork := errors.New("connection refused")
+
+	type step struct {
+		token     string
+		err       error         // load 返回的错误
+		wait      time.Duration // 执行前等待的时间
+		wantCalls int32         // 执行后 load 的累计调用次数
+	}
+	tests := []struct {
+		name        string
+		ttl         time.Duration
+		negativeTTL time.Duration
+		maxEntries  int
+		steps       []step
+	}{
+		{
+			name: "hit", ttl: time.Minute, negativeTTL: time.Minute, maxEntries: 10,
+			steps: []step{{token: "a", wantCalls: 1}, {token: "a", wantCalls: 1}},
+		},
+		{
+			name: "ttl expiry", ttl: 20 * time.Millisecond, negativeTTL: time.Minute, maxEntries: 10,
+			steps: []step{{token: "a", wantCalls: 1}, {token: "a", wait: 40 * time.Millisecond, wantCalls: 2}},
+		},
+		{
+			name: "rejected token is negatively cached", ttl: time.Minute, negativeTTL: time.Minute, maxEntries: 10,
+			steps: []step{{token: "a", err: errRejected, wantCalls: 1}, {token: "a", err: errRejected, wantCalls: 1}},
+		},
+		{
+			name: "negative cache expiry", ttl: time.Minute, negativeTTL: 20 * time.Millisecond, maxEntries: 10,
+			steps: []step{{token: "a", err: errRejected, wantCalls: 1}, {token: "a", err: errRejected, wait: 40 * time.Millisecond, wantCalls: 2}},
+		},
+		{
+			name: "transport error is not cached", ttl: time.Minute, negativeTTL: time.Minute, maxEntries: 10,
+			steps: []step{{token: "a", err: errNetwork, wantCalls: 1}, {token: "a", err: errNetwork, wantCalls: 2}},
+		},
+		{
+			name: "lru eviction", ttl: time.Minute, negativeTTL: time.Minute, maxEntries: 2,
+			steps: []step{
+				{token: "a", wantCalls: 1},
+				{token: "b", wantCalls: 2},
+				{token: "a", wantCalls: 2}, // a 变为最近使用
+				{token: "c", wantCalls: 3}, // 淘汰 b
+				{token: "a", wantCalls: 3},
+				{token: "b", wantCalls: 4},
+			},
+		},
+		{
+			name: "caching disabled", ttl: 0, negativeTTL: 0, maxEntries: 10,
+			steps: []step{{token: "a", wantCalls: 1}, {token: "a", wantCalls: 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := newTokenCache(tt.ttl, tt.negativeTTL, tt.maxEntries)
+			loader := &countingLoader{}
+			for i, s := range tt.steps {
+				time.Sleep(s.wait)
+				userInfo, err := cache.Get(context.Background(), s.token, loader.load(s.err))
+				if s.err != nil && !errors.Is(err, s.err) {
+					t.Errorf("step %d: err = %v, want %v", i, err, s.err)
+				}
+				if s.err == nil && (err != nil || userInfo == nil) {
+					t.Errorf("step %d: userInfo = %v, err = %v, want user", i, userInfo, err)
+				}
+				if calls := loader.calls.Load(); calls != s.wantCalls {
+					t.Errorf("step %d: load called %d times, want %d", i, calls, s.wantCalls)
+				}
+			}
+		})
+	}
+}
+
+func TestTokenCacheCoalescesConcurrentLoads(t *testing.T) {
+	cache := newTokenCache(time.Minute, time.Minute, 10)
+	var calls atomic.Int32
+	release := make(chan struct{})
+	load := func(ctx context.Context) (*UserInfoResp, error) {
+		calls.Add(1)
+		<-release
+		return &UserInfoResp{UserBasicResp: &UserBasicResp{Userid: 1}}, nil
+	}
+
+	const n = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cache.Get(context.Background(), "a", load); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("Get: %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("load called %d times, want 1", got)
+	}
+}
+
+func TestTokenCacheCallerCancelDoesNotAbortLoad(t *testing.T) {
+	cache := newTokenCache(time.Minute, time.Minute, 10)
+	var calls atomic.Int32
+	release := make(chan struct{})
+	load := func(ctx context.Context) (*UserInfoResp, error) {
+		calls.Add(1)
+		select {
+		case <-release:
+			return &UserInfoResp{UserBasicResp: &UserBasicResp{Userid: 1}}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := cache.Get(ctx, "a", load); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Get with canceled ctx: err = %v, want context.Canceled", err)
+	}
+
+	close(release)
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), time.Second)
+	defer waitCancel()
+	if err := cache.Wait(waitCtx); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	// 取消的调用方不影响共享的加载，结果已写入缓存
+	if _, err := cache.Get(context.Background(), "a", load); err != nil {
+		t.Fatalf("Get after load: %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("load called %d times, want 1", got)
+	}
+}
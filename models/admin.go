@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// AdminTokenHeader 管理接口令牌的请求头名称
+const AdminTokenHeader = "X-Admin-Token"
+
+// BuildInfo 构建版本信息
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"build_time,omitempty"`
+	Modified  bool   `json:"modified"` // 构建时工作区是否有未提交的修改
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+}
+
+// SetLogLevelRequest 修改日志级别请求
+type SetLogLevelRequest struct {
+	Level           string `json:"level"`            // trace, debug, info, warn, error
+	DurationSeconds int    `json:"duration_seconds"` // 大于0时到期自动恢复，0表示永久修改
+}
+
+// LogLevelResponse 当前日志级别
+type LogLevelResponse struct {
+	Level       string     `json:"level"`
+	RevertLevel string     `json:"revert_level,omitempty"` // 到期后恢复的级别
+	RevertAt    *time.Time `json:"revert_at,omitempty"`    // 自动恢复时间
+}
+
+// SetPprofRequest 开关 pprof 请求
+type SetPprofRequest struct {
+	Enabled         bool `json:"enabled"`
+	DurationSeconds int  `json:"duration_seconds"` // 开启时大于0则到期自动关闭
+}
+
+// PprofResponse pprof 状态
+type PprofResponse struct {
+	Enabled     bool       `json:"enabled"`
+	DisableAt   *time.Time `json:"disable_at,omitempty"` // 自动关闭时间
+	ProfilePath string     `json:"profile_path"`         // pprof 首页地址
+}
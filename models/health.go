@@ -0,0 +1,21 @@
+package models
+
+// 健康检查状态
+const (
+	HealthStatusOK      = "ok"      // 检查通过
+	HealthStatusFail    = "fail"    // 检查失败
+	HealthStatusSkipped = "skipped" // 功能未启用，跳过检查
+)
+
+// HealthCheckResult 单项依赖检查结果
+type HealthCheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// ReadinessResponse 就绪检查响应
+type ReadinessResponse struct {
+	Ready  bool                         `json:"ready"`
+	Checks map[string]HealthCheckResult `json:"checks"`
+}
@@ -0,0 +1,101 @@
+package models
+
+import "time"
+
+// 本地会议记录状态
+const (
+	MeetingStatusWaiting = "waiting" // 已创建，尚未开始
+	MeetingStatusStarted = "started" // 进行中
+	MeetingStatusEnded   = "ended"   // 已结束
+	MeetingStatusDeleted = "deleted" // 已删除
+)
+
+// MeetingRecord 本地保存的会议记录，关联创建会议的DooTask用户
+type MeetingRecord struct {
+	ID            int64            `json:"id"`
+	ZoomMeetingID int64            `json:"zoom_meeting_id"`
+	UUID          string           `json:"uuid"`
+	CreatorUserID int              `json:"creator_user_id"`
+	HostID        string           `json:"host_id"`
+	HostEmail     string           `json:"host_email"`
+	Topic         string           `json:"topic"`
+	Type          int              `json:"type"`
+	StartTime     time.Time        `json:"start_time"`
+	Duration      int              `json:"duration"`
+	Timezone      string           `json:"timezone"`
+	JoinURL       string           `json:"join_url"`
+	Settings      *MeetingSettings `json:"settings,omitempty"`
+	Recurrence    *Recurrence      `json:"recurrence,omitempty"`
+	Status        string           `json:"status"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+// NewMeetingRecord 根据Zoom创建会议响应生成本地会议记录
+func NewMeetingRecord(meeting *CreateMeetingResponse, creatorUserID int) *MeetingRecord {
+	return &MeetingRecord{
+		ZoomMeetingID: meeting.ID,
+		UUID:          meeting.UUID,
+		CreatorUserID: creatorUserID,
+		HostID:        meeting.HostID,
+		HostEmail:     meeting.HostEmail,
+		Topic:         meeting.Topic,
+		Type:          meeting.Type,
+		StartTime:     meeting.StartTime,
+		Duration:      meeting.Duration,
+		Timezone:      meeting.Timezone,
+		JoinURL:       meeting.JoinURL,
+		Settings:      meeting.Settings,
+		Recurrence:    meeting.Recurrence,
+		Status:        MeetingStatusWaiting,
+	}
+}
+
+// ListItem 转换为Zoom会议列表项
+func (r *MeetingRecord) ListItem() MeetingListItem {
+	return MeetingListItem{
+		UUID:      r.UUID,
+		ID:        r.ZoomMeetingID,
+		HostID:    r.HostID,
+		Topic:     r.Topic,
+		Type:      r.Type,
+		StartTime: r.StartTime,
+		Duration:  r.Duration,
+		Timezone:  r.Timezone,
+		CreatedAt: r.CreatedAt,
+		JoinURL:   r.JoinURL,
+	}
+}
+
+// 我的会议查询范围
+const (
+	MeetingScopeAll      = "all"      // 全部
+	MeetingScopeUpcoming = "upcoming" // 即将开始
+	MeetingScopeLive     = "live"     // 进行中
+	MeetingScopePast     = "past"     // 已结束
+)
+
+// MyMeetingsFilter 我的会议查询条件
+type MyMeetingsFilter struct {
+	UserID   int
+	Email    string
+	Scope    string
+	From     time.Time // 开始时间下限（含）
+	To       time.Time // 开始时间上限（不含）
+	Page     int
+	PageSize int
+}
+
+// MyMeetingItem 我的会议列表项
+type MyMeetingItem struct {
+	*MeetingRecord
+	IsCreator bool `json:"is_creator"` // 是否为会议创建者，否则为受邀者
+}
+
+// MyMeetingsResponse 我的会议列表响应
+type MyMeetingsResponse struct {
+	Page         int             `json:"page"`
+	PageSize     int             `json:"page_size"`
+	TotalRecords int             `json:"total_records"`
+	Meetings     []MyMeetingItem `json:"meetings"`
+}
@@ -0,0 +1,101 @@
+package models
+
+import "encoding/json"
+
+// Zoom Webhook 事件类型
+const (
+	WebhookEventURLValidation      = "endpoint.url_validation"
+	WebhookEventMeetingStarted     = "meeting.started"
+	WebhookEventMeetingEnded       = "meeting.ended"
+	WebhookEventParticipantJoined  = "meeting.participant_joined"
+	WebhookEventParticipantLeft    = "meeting.participant_left"
+	WebhookEventRecordingCompleted = "recording.completed"
+)
+
+// WebhookEvent Zoom Webhook 通用事件结构
+type WebhookEvent struct {
+	Event         string          `json:"event"`
+	EventTS       int64           `json:"event_ts"`
+	Payload       json.RawMessage `json:"payload"`
+	DownloadToken string          `json:"download_token,omitempty"` // 仅录制事件携带
+}
+
+// WebhookURLValidationPayload URL验证事件负载
+type WebhookURLValidationPayload struct {
+	PlainToken string `json:"plainToken"`
+}
+
+// WebhookURLValidationResponse URL验证响应
+type WebhookURLValidationResponse struct {
+	PlainToken     string `json:"plainToken"`
+	EncryptedToken string `json:"encryptedToken"`
+}
+
+// WebhookMeetingPayload 会议事件负载（meeting.started、meeting.ended、participant_joined/left）
+type WebhookMeetingPayload struct {
+	AccountID string               `json:"account_id"`
+	Object    WebhookMeetingObject `json:"object"`
+}
+
+// WebhookMeetingObject 会议事件中的会议信息
+type WebhookMeetingObject struct {
+	ID          json.Number         `json:"id"` // Zoom 会以字符串或数字形式发送会议号
+	UUID        string              `json:"uuid"`
+	HostID      string              `json:"host_id"`
+	Topic       string              `json:"topic"`
+	Type        int                 `json:"type"`
+	StartTime   string              `json:"start_time,omitempty"`
+	EndTime     string              `json:"end_time,omitempty"`
+	Duration    int                 `json:"duration"`
+	Timezone    string              `json:"timezone,omitempty"`
+	Participant *WebhookParticipant `json:"participant,omitempty"`
+}
+
+// WebhookParticipant 参会者信息
+type WebhookParticipant struct {
+	UserID            string `json:"user_id"`
+	UserName          string `json:"user_name"`
+	ID                string `json:"id,omitempty"`
+	ParticipantUUID   string `json:"participant_uuid,omitempty"`
+	ParticipantUserID string `json:"participant_user_id,omitempty"`
+	Email             string `json:"email,omitempty"`
+	JoinTime          string `json:"join_time,omitempty"`
+	LeaveTime         string `json:"leave_time,omitempty"`
+	LeaveReason       string `json:"leave_reason,omitempty"`
+}
+
+// WebhookRecordingPayload 录制完成事件负载
+type WebhookRecordingPayload struct {
+	AccountID string                 `json:"account_id"`
+	Object    WebhookRecordingObject `json:"object"`
+}
+
+// WebhookRecordingObject 录制事件中的会议录制信息
+type WebhookRecordingObject struct {
+	ID             json.Number            `json:"id"`
+	UUID           string                 `json:"uuid"`
+	HostID         string                 `json:"host_id"`
+	HostEmail      string                 `json:"host_email,omitempty"`
+	Topic          string                 `json:"topic"`
+	Type           int                    `json:"type"`
+	StartTime      string                 `json:"start_time"`
+	Duration       int                    `json:"duration"`
+	TotalSize      int64                  `json:"total_size"`
+	RecordingCount int                    `json:"recording_count"`
+	ShareURL       string                 `json:"share_url"`
+	RecordingFiles []WebhookRecordingFile `json:"recording_files"`
+}
+
+// WebhookRecordingFile 录制文件
+type WebhookRecordingFile struct {
+	ID             string `json:"id"`
+	MeetingID      string `json:"meeting_id"`
+	RecordingStart string `json:"recording_start"`
+	RecordingEnd   string `json:"recording_end"`
+	FileType       string `json:"file_type"`
+	FileSize       int64  `json:"file_size"`
+	PlayURL        string `json:"play_url"`
+	DownloadURL    string `json:"download_url"`
+	Status         string `json:"status"`
+	RecordingType  string `json:"recording_type"`
+}
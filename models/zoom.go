@@ -2,12 +2,16 @@ package models
 
 import "time"
 
+// RequestIDHeader 请求ID的请求头和响应头名称
+const RequestIDHeader = "X-Request-ID"
+
 // CommonResponse 通用API响应结构
 type CommonResponse struct {
-	Code    int         `json:"code"`    // 状态码：200成功，其他为错误码
-	Message string      `json:"message"` // 响应消息
-	Data    interface{} `json:"data"`    // 响应数据
-	Success bool        `json:"success"` // 是否成功
+	Code      int         `json:"code"`                 // 状态码：200成功，其他为错误码
+	Message   string      `json:"message"`              // 响应消息
+	Data      interface{} `json:"data"`                 // 响应数据
+	Success   bool        `json:"success"`              // 是否成功
+	RequestID string      `json:"request_id,omitempty"` // 请求ID，反馈问题时用于查找日志
 }
 
 // NewSuccessResponse 创建成功响应
@@ -40,13 +44,29 @@ func NewErrorResponse(code int, message string, data ...interface{}) *CommonResp
 
 // ZoomSignatureRequest JWT签名请求
 type ZoomSignatureRequest struct {
-	MeetingNumber string `json:"meetingNumber"`
-	Role          int    `json:"role"`
+	MeetingNumber   string `json:"meetingNumber"`
+	Role            int    `json:"role"`
+	VideoWebRTCMode *int   `json:"videoWebRtcMode,omitempty"` // 可选，1启用WebRTC视频
+	IncludeOBF      bool   `json:"includeObf,omitempty"`      // 可选，同时返回OBF令牌（加入其他账号的会议时需要）
 }
 
 // ZoomSignatureResponse JWT签名响应
 type ZoomSignatureResponse struct {
 	Signature string `json:"signature"`
+	Role      int    `json:"role"`          // 实际签发的角色，可能低于请求的角色
+	ZAK       string `json:"zak,omitempty"` // 主持人开会所需的ZAK令牌
+	OBF       string `json:"obf,omitempty"` // 代表用户加入会议的OBF令牌
+}
+
+// 用户令牌类型
+const (
+	UserTokenTypeZAK = "zak"
+	UserTokenTypeOBF = "onbehalf"
+)
+
+// ZoomUserTokenResponse Zoom用户令牌响应
+type ZoomUserTokenResponse struct {
+	Token string `json:"token"`
 }
 
 // ConfigResponse 配置响应
@@ -72,6 +92,60 @@ type CreateMeetingRequest struct {
 	Password   string           `json:"password,omitempty"`
 	Agenda     string           `json:"agenda,omitempty"`
 	Settings   *MeetingSettings `json:"settings,omitempty"`
+	Recurrence *Recurrence      `json:"recurrence,omitempty"`
+}
+
+// 会议类型
+const (
+	MeetingTypeInstant            = 1 // 即时会议
+	MeetingTypeScheduled          = 2 // 预定会议
+	MeetingTypeRecurringNoFixed   = 3 // 定期会议（无固定时间）
+	MeetingTypeRecurringFixedTime = 8 // 定期会议（固定时间）
+)
+
+// 定期会议重复类型
+const (
+	RecurrenceTypeDaily   = 1 // 每天
+	RecurrenceTypeWeekly  = 2 // 每周
+	RecurrenceTypeMonthly = 3 // 每月
+)
+
+// Recurrence 定期会议重复规则，仅用于固定时间的定期会议(type=8)
+type Recurrence struct {
+	Type           int    `json:"type"`                       // 重复类型: 1=每天, 2=每周, 3=每月
+	RepeatInterval int    `json:"repeat_interval,omitempty"`  // 重复间隔（天/周/月）
+	WeeklyDays     string `json:"weekly_days,omitempty"`      // 每周的哪几天，逗号分隔: 1=周日 ... 7=周六
+	MonthlyDay     int    `json:"monthly_day,omitempty"`      // 每月的第几天 1-31
+	MonthlyWeek    int    `json:"monthly_week,omitempty"`     // 每月的第几周: -1=最后一周, 1-4
+	MonthlyWeekDay int    `json:"monthly_week_day,omitempty"` // 与monthly_week配合使用: 1=周日 ... 7=周六
+	EndTimes       int    `json:"end_times,omitempty"`        // 重复次数，不能与end_date_time同时使用
+	EndDateTime    string `json:"end_date_time,omitempty"`    // 结束时间（ISO 8601 UTC格式）
+}
+
+// Occurrence 定期会议的单次会议
+type Occurrence struct {
+	OccurrenceID string    `json:"occurrence_id"`
+	StartTime    time.Time `json:"start_time"`
+	Duration     int       `json:"duration"`
+	Status       string    `json:"status"` // available, deleted
+}
+
+// ListOccurrencesResponse 定期会议单次会议列表响应
+type ListOccurrencesResponse struct {
+	MeetingID   int64        `json:"meeting_id"`
+	Topic       string       `json:"topic"`
+	Type        int          `json:"type"`
+	Recurrence  *Recurrence  `json:"recurrence,omitempty"`
+	Occurrences []Occurrence `json:"occurrences"`
+}
+
+// UpdateOccurrenceRequest 更新单次会议请求，只提交需要修改的字段
+type UpdateOccurrenceRequest struct {
+	StartTime *string                `json:"start_time,omitempty"`
+	Duration  *int                   `json:"duration,omitempty"`
+	Timezone  *string                `json:"timezone,omitempty"`
+	Agenda    *string                `json:"agenda,omitempty"`
+	Settings  *MeetingSettingsUpdate `json:"settings,omitempty"`
 }
 
 // MeetingSettings 会议设置
@@ -81,6 +155,52 @@ type MeetingSettings struct {
 	JoinBeforeHost   bool `json:"join_before_host,omitempty"`
 	MuteUponEntry    bool `json:"mute_upon_entry,omitempty"`
 	WaitingRoom      bool `json:"waiting_room,omitempty"`
+	// 备用主持人邮箱，多个用逗号或分号分隔
+	AlternativeHosts string `json:"alternative_hosts,omitempty"`
+	// 受邀参会者
+	MeetingInvitees []MeetingInvitee `json:"meeting_invitees,omitempty"`
+}
+
+// MeetingSettingsUpdate 更新会议时提交的设置，只包含需要修改的字段
+// 布尔字段使用指针，false 也会提交给Zoom，从而可以关闭某项设置
+type MeetingSettingsUpdate struct {
+	HostVideo        *bool            `json:"host_video,omitempty"`
+	ParticipantVideo *bool            `json:"participant_video,omitempty"`
+	JoinBeforeHost   *bool            `json:"join_before_host,omitempty"`
+	MuteUponEntry    *bool            `json:"mute_upon_entry,omitempty"`
+	WaitingRoom      *bool            `json:"waiting_room,omitempty"`
+	AlternativeHosts *string          `json:"alternative_hosts,omitempty"`
+	MeetingInvitees  []MeetingInvitee `json:"meeting_invitees,omitempty"`
+}
+
+// ApplyTo 将提交的字段合并到已有设置，未提交的字段保持不变
+func (u *MeetingSettingsUpdate) ApplyTo(settings *MeetingSettings) {
+	if u.HostVideo != nil {
+		settings.HostVideo = *u.HostVideo
+	}
+	if u.ParticipantVideo != nil {
+		settings.ParticipantVideo = *u.ParticipantVideo
+	}
+	if u.JoinBeforeHost != nil {
+		settings.JoinBeforeHost = *u.JoinBeforeHost
+	}
+	if u.MuteUponEntry != nil {
+		settings.MuteUponEntry = *u.MuteUponEntry
+	}
+	if u.WaitingRoom != nil {
+		settings.WaitingRoom = *u.WaitingRoom
+	}
+	if u.AlternativeHosts != nil {
+		settings.AlternativeHosts = *u.AlternativeHosts
+	}
+	if u.MeetingInvitees != nil {
+		settings.MeetingInvitees = u.MeetingInvitees
+	}
+}
+
+// MeetingInvitee 受邀参会者
+type MeetingInvitee struct {
+	Email string `json:"email"`
 }
 
 // CreateMeetingResponse 创建会议响应
@@ -102,6 +222,8 @@ type CreateMeetingResponse struct {
 	PSTNPassword      string           `json:"pstn_password"`
 	EncryptedPassword string           `json:"encrypted_password"`
 	Settings          *MeetingSettings `json:"settings"`
+	Recurrence        *Recurrence      `json:"recurrence,omitempty"`
+	Occurrences       []Occurrence     `json:"occurrences,omitempty"`
 }
 
 // JWTHeader JWT头部
@@ -110,10 +232,126 @@ type JWTHeader struct {
 	Typ string `json:"typ"`
 }
 
-// JWTPayload JWT负载
+// MeetingSDKPayload Meeting SDK 签名负载
+type MeetingSDKPayload struct {
+	AppKey          string `json:"appKey"`
+	SDKKey          string `json:"sdkKey"`
+	Mn              string `json:"mn"`
+	Role            int    `json:"role"`
+	Iat             int64  `json:"iat"`
+	Exp             int64  `json:"exp"`
+	TokenExp        int64  `json:"tokenExp"`
+	VideoWebRTCMode *int   `json:"video_webrtc_mode,omitempty"`
+}
+
+// VideoSDKTokenRequest Video SDK 会话令牌请求
+type VideoSDKTokenRequest struct {
+	SessionName          string   `json:"sessionName"`                    // 会话名称，最长200个字符
+	Role                 int      `json:"role"`                           // 0=参与者, 1=主持人
+	SessionKey           string   `json:"sessionKey,omitempty"`           // 可选，会话密钥，最长36个字符
+	GeoRegions           []string `json:"geoRegions,omitempty"`           // 可选，数据中心区域，如 US、AU、CN
+	CloudRecordingOption *int     `json:"cloudRecordingOption,omitempty"` // 可选，0=合成录制, 1=分别录制每个用户
+}
+
+// VideoSDKTokenResponse Video SDK 会话令牌响应
+type VideoSDKTokenResponse struct {
+	Token        string `json:"token"`
+	SessionName  string `json:"sessionName"`
+	UserIdentity string `json:"userIdentity,omitempty"`
+	ExpiresAt    int64  `json:"expiresAt"`
+}
+
+// VideoSDKPayload Video SDK 令牌负载
+type VideoSDKPayload struct {
+	AppKey               string `json:"app_key"`
+	Tpc                  string `json:"tpc"`
+	RoleType             int    `json:"role_type"`
+	SessionKey           string `json:"session_key,omitempty"`
+	UserIdentity         string `json:"user_identity,omitempty"`
+	GeoRegions           string `json:"geo_regions,omitempty"`
+	CloudRecordingOption *int   `json:"cloud_recording_option,omitempty"`
+	Version              int    `json:"version"`
+	Iat                  int64  `json:"iat"`
+	Exp                  int64  `json:"exp"`
+}
+
+// JWTPayload 旧版JWT应用签名负载
 type JWTPayload struct {
 	Iss  string `json:"iss"`
 	Exp  int64  `json:"exp"`
 	Mn   string `json:"mn"`
 	Role int    `json:"role"`
-}
\ No newline at end of file
+}
+
+// ListMeetingsRequest 会议列表查询参数
+type ListMeetingsRequest struct {
+	Type          string `json:"type,omitempty"`            // scheduled, live, upcoming, upcoming_meetings, previous_meetings
+	PageSize      int    `json:"page_size,omitempty"`       // 每页数量，最大300
+	PageNumber    int    `json:"page_number,omitempty"`     // 页码
+	NextPageToken string `json:"next_page_token,omitempty"` // 下一页令牌
+	From          string `json:"from,omitempty"`            // 开始日期 yyyy-mm-dd
+	To            string `json:"to,omitempty"`              // 结束日期 yyyy-mm-dd
+}
+
+// MeetingListItem 会议列表项
+type MeetingListItem struct {
+	UUID      string    `json:"uuid"`
+	ID        int64     `json:"id"`
+	HostID    string    `json:"host_id"`
+	Topic     string    `json:"topic"`
+	Type      int       `json:"type"`
+	StartTime time.Time `json:"start_time"`
+	Duration  int       `json:"duration"`
+	Timezone  string    `json:"timezone"`
+	Agenda    string    `json:"agenda,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	JoinURL   string    `json:"join_url"`
+}
+
+// ListMeetingsResponse 会议列表响应
+type ListMeetingsResponse struct {
+	PageCount     int               `json:"page_count,omitempty"`
+	PageNumber    int               `json:"page_number,omitempty"`
+	PageSize      int               `json:"page_size"`
+	TotalRecords  int               `json:"total_records"`
+	NextPageToken string            `json:"next_page_token"`
+	Meetings      []MeetingListItem `json:"meetings"`
+}
+
+// UpdateMeetingRequest 更新会议请求，只提交需要修改的字段
+type UpdateMeetingRequest struct {
+	Topic      *string                `json:"topic,omitempty"`
+	Type       *int                   `json:"type,omitempty"`
+	StartTime  *string                `json:"start_time,omitempty"`
+	Duration   *int                   `json:"duration,omitempty"`
+	Timezone   *string                `json:"timezone,omitempty"`
+	Password   *string                `json:"password,omitempty"`
+	Agenda     *string                `json:"agenda,omitempty"`
+	Settings   *MeetingSettingsUpdate `json:"settings,omitempty"`
+	Recurrence *Recurrence            `json:"recurrence,omitempty"`
+}
+
+// UpdateMeetingStatusRequest 更新会议状态请求
+type UpdateMeetingStatusRequest struct {
+	Action string `json:"action"` // end: 结束会议, recover: 恢复已删除的会议
+}
+
+// ZoomErrorResponse Zoom API 错误响应
+type ZoomErrorResponse struct {
+	Code    int              `json:"code"`
+	Message string           `json:"message"`
+	Errors  []ZoomFieldError `json:"errors,omitempty"`
+}
+
+// ZoomFieldError Zoom API 字段级错误
+type ZoomFieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ZoomErrorDetail 返回给客户端的Zoom错误详情
+type ZoomErrorDetail struct {
+	ZoomCode    int              `json:"zoom_code,omitempty"`
+	ZoomMessage string           `json:"zoom_message,omitempty"`
+	Errors      []ZoomFieldError `json:"errors,omitempty"`
+}
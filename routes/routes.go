@@ -1,41 +1,103 @@
 package routes
 
 import (
+	"context"
+	"net/http"
+
 	"zoom-app-server/config"
 	"zoom-app-server/handlers"
+	"zoom-app-server/metrics"
 	"zoom-app-server/middleware"
 	"zoom-app-server/services"
+	"zoom-app-server/store"
+	"zoom-app-server/tracing"
+	"zoom-app-server/utils/route"
 
 	"github.com/gorilla/mux"
 )
 
-// SetupRoutes 设置路由
-func SetupRoutes(cfg *config.Config) *mux.Router {
+// SetupRoutes 设置路由，返回的 shutdown 用于在服务退出时等待并释放后台任务
+func SetupRoutes(cfg *config.Config, meetingStore *store.Store) (*mux.Router, func(ctx context.Context) error) {
 	// 创建服务实例
 	zoomService := services.NewZoomService(cfg)
+	webhookService := services.NewWebhookService(cfg, meetingStore)
 
 	// 创建处理器实例
-	zoomHandler := handlers.NewZoomHandler(cfg, zoomService)
+	zoomHandler := handlers.NewZoomHandler(cfg, zoomService, meetingStore)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	adminHandler := handlers.NewAdminHandler(cfg)
 
 	// 创建中间件实例
-	dooTaskMiddleware := middleware.NewDooTaskMiddleware(cfg)
+	dooTaskClient := middleware.NewDooTaskHTTPClient(cfg)
+	dooTaskMiddleware := middleware.NewDooTaskMiddlewareWithClient(cfg, dooTaskClient)
+	healthHandler := handlers.NewHealthHandler(cfg, zoomService, dooTaskClient, meetingStore)
 
 	// 创建路由器
 	router := mux.NewRouter()
+	router.Use(route.Middleware, tracing.RouteMiddleware)
+
+	// Prometheus 指标（nginx 不对外代理，仅供容器内或内网抓取）
+	router.Handle("/metrics", metrics.Handler()).Methods("GET")
+
+	// 存活与就绪检查（不需要认证）
+	router.HandleFunc("/healthz", healthHandler.HandleHealthz).Methods("GET")
+	router.HandleFunc("/readyz", healthHandler.HandleReadyz).Methods("GET")
+
+	// 所有接口都在 /api 下，按路由声明认证策略
+	apiRouter := router.PathPrefix("/api").Subrouter()
+	handle := func(path string, policy middleware.AuthPolicy, handler http.HandlerFunc, methods ...string) {
+		apiRouter.Handle(path, dooTaskMiddleware.WithPolicy(policy)(handler)).Methods(methods...)
+	}
 
-	// 创建需要认证的子路由
-	authRouter := router.PathPrefix("/api").Subrouter()
-	authRouter.Use(dooTaskMiddleware.AuthMiddleware)
+	// Zoom Webhook 接口（使用签名校验，不走DooTask认证）
+	handle("/webhooks/zoom", middleware.AuthNone, webhookHandler.HandleZoomWebhook, "POST")
 
 	// 注册需要强制认证的路由
 	// 创建会议接口（需要认证）
-	authRouter.HandleFunc("/meetings", zoomHandler.HandleCreateMeeting).Methods("POST")
+	handle("/meetings", middleware.AuthRequired, zoomHandler.HandleCreateMeeting, "POST")
+	// 会议列表、详情、更新、删除、结束会议接口（需要认证）
+	handle("/meetings", middleware.AuthRequired, zoomHandler.HandleListMeetings, "GET")
+	handle("/meetings/{id}", middleware.AuthRequired, zoomHandler.HandleGetMeeting, "GET")
+	handle("/meetings/{id}", middleware.AuthRequired, zoomHandler.HandleUpdateMeeting, "PATCH")
+	handle("/meetings/{id}", middleware.AuthRequired, zoomHandler.HandleDeleteMeeting, "DELETE")
+	handle("/meetings/{id}/status", middleware.AuthRequired, zoomHandler.HandleUpdateMeetingStatus, "PUT")
+	// 定期会议单次会议管理接口（需要认证）
+	handle("/meetings/{id}/occurrences", middleware.AuthRequired, zoomHandler.HandleListOccurrences, "GET")
+	handle("/meetings/{id}/occurrences/{occurrence_id}", middleware.AuthRequired, zoomHandler.HandleUpdateOccurrence, "PATCH")
+	handle("/meetings/{id}/occurrences/{occurrence_id}", middleware.AuthRequired, zoomHandler.HandleDeleteOccurrence, "DELETE")
+	// 当前用户的会议列表（需要认证）
+	handle("/me/meetings", middleware.AuthRequired, zoomHandler.HandleListMyMeetings, "GET")
+
+	// ZAK/OBF 令牌接口（需要认证）
+	handle("/tokens/zak", middleware.AuthRequired, zoomHandler.HandleGetZAKToken, "GET")
+	handle("/tokens/obf", middleware.AuthRequired, zoomHandler.HandleGetOBFToken, "GET")
+
+	// Video SDK 会话令牌接口（需要认证）
+	handle("/video-sdk/token", middleware.AuthRequired, zoomHandler.HandleGenerateVideoSDKToken, "POST")
 
 	// 注册可选认证的路由
 	// JWT签名生成接口（可选认证）
-	authRouter.HandleFunc("/signature", zoomHandler.HandleGenerateSignature).Methods("POST")
+	handle("/signature", middleware.AuthOptional, zoomHandler.HandleGenerateSignature, "POST")
 	// 获取配置接口（可选认证）
-	authRouter.HandleFunc("/config", zoomHandler.HandleGetConfig).Methods("GET")
+	handle("/config", middleware.AuthOptional, zoomHandler.HandleGetConfig, "GET")
+
+	// 管理与诊断接口（仅管理员或管理接口令牌）
+	handle("/admin/log-level", middleware.AuthAdmin, adminHandler.HandleGetLogLevel, "GET")
+	handle("/admin/log-level", middleware.AuthAdmin, adminHandler.HandleSetLogLevel, "PUT")
+	handle("/admin/config", middleware.AuthAdmin, adminHandler.HandleGetConfig, "GET")
+	handle("/admin/version", middleware.AuthAdmin, adminHandler.HandleGetVersion, "GET")
+	handle("/admin/pprof", middleware.AuthAdmin, adminHandler.HandleGetPprof, "GET")
+	handle("/admin/pprof", middleware.AuthAdmin, adminHandler.HandleSetPprof, "PUT")
+	apiRouter.PathPrefix("/admin/debug/pprof/").Handler(
+		dooTaskMiddleware.WithPolicy(middleware.AuthAdmin)(http.HandlerFunc(adminHandler.HandlePprof)),
+	).Methods("GET", "POST")
+
+	shutdown := func(ctx context.Context) error {
+		if err := dooTaskMiddleware.Close(ctx); err != nil {
+			return err
+		}
+		return zoomService.Close(ctx)
+	}
 
-	return router
+	return router, shutdown
 }
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"zoom-app-server/store"
+	"zoom-app-server/utils/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+// 签名角色
+const (
+	SignatureRoleParticipant = 0 // 参会者
+	SignatureRoleHost        = 1 // 主持人
+)
+
+// SignatureRequester 申请签名的用户，Email 只能是已验证的邮箱
+type SignatureRequester struct {
+	UserID  int
+	Email   string // 已验证的邮箱，未验证时为空
+	IsAdmin bool
+}
+
+// SignaturePolicy 签名角色策略
+// 只有会议创建者、Zoom主持人或备用主持人以及DooTask管理员可以获得主持人签名，其他人一律降级为参会者
+type SignaturePolicy struct {
+	zoomService  ZoomClient
+	meetingStore *store.Store
+}
+
+// NewSignaturePolicy 创建签名策略实例
+func NewSignaturePolicy(zoomService ZoomClient, meetingStore *store.Store) *SignaturePolicy {
+	return &SignaturePolicy{
+		zoomService:  zoomService,
+		meetingStore: meetingStore,
+	}
+}
+
+// ResolveRole 返回允许签发的角色，requester为nil表示匿名用户
+func (p *SignaturePolicy) ResolveRole(ctx context.Context, requester *SignatureRequester, meetingNumber string, requestedRole int) int {
+	if requestedRole != SignatureRoleHost {
+		return SignatureRoleParticipant
+	}
+
+	fields := logrus.Fields{
+		"meeting_number": meetingNumber,
+		"requested_role": requestedRole,
+	}
+	if requester == nil {
+		logger.WithFields(fields).Warn("Host signature denied for anonymous user, issuing participant signature")
+		return SignatureRoleParticipant
+	}
+	fields["user_id"] = requester.UserID
+	fields["email"] = requester.Email
+
+	if reason := p.hostReason(ctx, requester, meetingNumber); reason != "" {
+		fields["reason"] = reason
+		logger.WithFields(fields).Info("Host signature granted")
+		return SignatureRoleHost
+	}
+
+	logger.WithFields(fields).Warn("Host signature denied, issuing participant signature")
+	return SignatureRoleParticipant
+}
+
+// CanManageMeeting 判断用户能否查看和管理会议，规则与主持人签名相同，requester为nil时不允许
+func (p *SignaturePolicy) CanManageMeeting(ctx context.Context, requester *SignatureRequester, meetingID string) bool {
+	if requester == nil {
+		return false
+	}
+	reason := p.hostReason(ctx, requester, meetingID)
+	if reason == "" {
+		logger.WithFields(logrus.Fields{
+			"meeting_id": meetingID,
+			"user_id":    requester.UserID,
+		}).Warn("Meeting access denied")
+		return false
+	}
+	return true
+}
+
+// hostReason 判断用户是否为会议的主持人（含创建者和管理员），返回授权原因，空字符串表示不是
+func (p *SignaturePolicy) hostReason(ctx context.Context, requester *SignatureRequester, meetingNumber string) string {
+	if requester.IsAdmin {
+		return "dootask_admin"
+	}
+
+	// 优先查本地会议记录，避免请求Zoom
+	if meetingID, err := strconv.ParseInt(meetingNumber, 10, 64); err == nil {
+		record, err := p.meetingStore.GetMeetingByZoomID(meetingID)
+		switch {
+		case err == nil:
+			if record.CreatorUserID == requester.UserID {
+				return "meeting_creator"
+			}
+		case !errors.Is(err, store.ErrNotFound):
+			logger.WithError(err).WithField("meeting_number", meetingNumber).Error("Failed to load meeting record for signature policy")
+		}
+	}
+
+	if requester.Email == "" {
+		return ""
+	}
+	meeting, err := p.zoomService.GetMeeting(ctx, meetingNumber)
+	if err != nil {
+		logger.WithError(err).WithField("meeting_number", meetingNumber).Warn("Failed to load Zoom meeting for signature policy")
+		return ""
+	}
+	if strings.EqualFold(meeting.HostEmail, requester.Email) {
+		return "zoom_host"
+	}
+	if meeting.Settings != nil && containsEmail(meeting.Settings.AlternativeHosts, requester.Email) {
+		return "alternative_host"
+	}
+	return ""
+}
+
+// containsEmail 判断以逗号或分号分隔的邮箱列表是否包含指定邮箱
+func containsEmail(list, email string) bool {
+	for _, item := range strings.FieldsFunc(list, func(r rune) bool { return r == ',' || r == ';' }) {
+		if strings.EqualFold(strings.TrimSpace(item), email) {
+			return true
+		}
+	}
+	return false
+}
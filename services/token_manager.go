@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"zoom-app-server/metrics"
+	"zoom-app-server/models"
+	"zoom-app-server/utils/logger"
+)
+
+// tokenMinValidity 令牌剩余有效期低于该值时必须同步刷新
+const tokenMinValidity = 30 * time.Second
+
+// tokenFetcher 从Zoom获取新令牌的函数
+type tokenFetcher func(ctx context.Context) (*models.OAuthTokenResponse, error)
+
+// tokenCall 正在进行中的令牌刷新
+type tokenCall struct {
+	done  chan struct{}
+	token *models.OAuthTokenResponse
+	err   error
+}
+
+// tokenManager 缓存Server-To-Server OAuth令牌
+// 在过期前主动刷新，并将并发刷新合并为一次上游请求
+type tokenManager struct {
+	fetch         tokenFetcher
+	refreshBefore time.Duration
+	fetchTimeout  time.Duration
+
+	mu        sync.Mutex
+	token     *models.OAuthTokenResponse
+	expiresAt time.Time
+	inflight  *tokenCall
+	refreshes sync.WaitGroup
+}
+
+// newTokenManager 创建令牌管理器，fetchTimeout为单次刷新的期限，<=0表示不限制
+func newTokenManager(fetch tokenFetcher, refreshBefore, fetchTimeout time.Duration) *tokenManager {
+	if refreshBefore < 0 {
+		refreshBefore = 0
+	}
+	return &tokenManager{
+		fetch:         fetch,
+		refreshBefore: refreshBefore,
+		fetchTimeout:  fetchTimeout,
+	}
+}
+
+// Get 获取可用的令牌
+// 令牌进入刷新窗口后仍返回缓存值，同时在后台刷新；即将过期时等待刷新完成
+// 刷新由所有等待者共享，ctx取消只会让当前调用方提前返回，不会中断刷新
+func (m *tokenManager) Get(ctx context.Context) (*models.OAuthTokenResponse, error) {
+	m.mu.Lock()
+	now := time.Now()
+	if m.token != nil {
+		remaining := m.expiresAt.Sub(now)
+		if remaining > m.refreshBefore {
+			token := m.token
+			m.mu.Unlock()
+			metrics.ZoomOAuthTokenCache.WithLabelValues("hit").Inc()
+			return token, nil
+		}
+		if remaining > tokenMinValidity {
+			token := m.token
+			metrics.ZoomOAuthTokenCache.WithLabelValues("hit").Inc()
+			if m.inflight == nil {
+				call := m.startRefreshLocked()
+				go m.runRefresh(ctx, call)
+			}
+			m.mu.Unlock()
+			return token, nil
+		}
+	}
+
+	metrics.ZoomOAuthTokenCache.WithLabelValues("miss").Inc()
+	call := m.inflight
+	if call == nil {
+		call = m.startRefreshLocked()
+		go m.runRefresh(ctx, call)
+	}
+	m.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.token, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Invalidate 作废指定的令牌，下次Get时重新获取
+// 只有当缓存中的令牌与传入值一致时才会作废，避免覆盖已刷新的新令牌
+func (m *tokenManager) Invalidate(accessToken string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.token != nil && m.token.AccessToken == accessToken {
+		m.token = nil
+		m.expiresAt = time.Time{}
+	}
+}
+
+// startRefreshLocked 登记一次新的刷新，调用方需持有锁
+func (m *tokenManager) startRefreshLocked() *tokenCall {
+	call := &tokenCall{done: make(chan struct{})}
+	m.inflight = call
+	m.refreshes.Add(1)
+	return call
+}
+
+// runRefresh 执行刷新并唤醒所有等待者
+// 刷新沿用触发者ctx中的追踪信息，但不受其取消影响
+func (m *tokenManager) runRefresh(ctx context.Context, call *tokenCall) {
+	defer m.refreshes.Done()
+	ctx = context.WithoutCancel(ctx)
+	if m.fetchTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.fetchTimeout)
+		defer cancel()
+	}
+	token, err := m.fetch(ctx)
+
+	m.mu.Lock()
+	if err == nil {
+		m.token = token
+		m.expiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+		logger.WithField("expires_at", m.expiresAt.Format(time.RFC3339)).Debug("Zoom OAuth token refreshed")
+		metrics.ZoomOAuthTokenFetches.WithLabelValues("success").Inc()
+	} else {
+		logger.WithError(err).Warn("Failed to refresh Zoom OAuth token")
+		metrics.ZoomOAuthTokenFetches.WithLabelValues("failure").Inc()
+	}
+	m.inflight = nil
+	m.mu.Unlock()
+
+	call.token, call.err = token, err
+	close(call.done)
+}
+
+// Wait 等待进行中的刷新结束，ctx到期时提前返回
+func (m *tokenManager) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		m.refreshes.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"zoom-app-server/config"
+	"zoom-app-server/models"
+	"zoom-app-server/utils/logger"
+)
+
+func TestMain(m *testing.M) {
+	logger.InitLogger(&logger.LogConfig{Level: "error", Format: "text", Output: "stdout"})
+	os.Exit(m.Run())
+}
+
+// oauthServer 模拟Zoom OAuth令牌接口，令牌依次为 token-1、token-2……
+// blockFrom 大于0时，第 blockFrom 次及之后的请求会等待 gate 关闭后才响应
+type oauthServer struct {
+	*httptest.Server
+	expiresIn int
+	blockFrom int32
+	gate      chan struct{}
+	calls     atomic.Int32
+}
+
+func newOAuthServer(t *testing.T, expiresIn int, blockFrom int32) *oauthServer {
+	t.Helper()
+	s := &oauthServer{expiresIn: expiresIn, blockFrom: blockFrom, gate: make(chan struct{})}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := s.calls.Add(1)
+		if s.blockFrom > 0 && n >= s.blockFrom {
+			<-s.gate
+		}
+		json.NewEncoder(w).Encode(models.OAuthTokenResponse{
+			AccessToken: fmt.Sprintf("token-%d", n),
+			TokenType:   "bearer",
+			ExpiresIn:   s.expiresIn,
+		})
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// newTestZoomService 创建指向测试服务器的Zoom服务
+func newTestZoomService(t *testing.T, baseURL string, refreshBefore int) *ZoomService {
+	t.Helper()
+	z := NewZoomService(&config.Config{
+		ZoomAccountID:          "account",
+		ZoomClientID:           "client",
+		ZoomClientSecret:       "secret",
+		ZoomOAuthBaseURL:       baseURL,
+		ZoomAPIBaseURL:         baseURL + "/v2",
+		ZoomTokenRefreshBefore: refreshBefore,
+		ZoomHTTPTimeout:        5,
+		ZoomRequestTimeout:     10,
+	})
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		z.Close(ctx)
+	})
+	return z
+}
+
+// mustGetToken 获取令牌并返回访问令牌字符串
+func mustGetToken(t *testing.T, z *ZoomService) string {
+	t.Helper()
+	token, err := z.GetOAuthToken(context.Background())
+	if err != nil {
+		t.Fatalf("GetOAuthToken: %v", err)
+	}
+	return token.AccessToken
+}
+
+func TestTokenManagerCachesToken(t *testing.T) {
+	server := newOAuthServer(t, 3600, 0)
+	z := newTestZoomService(t, server.URL, 300)
+
+	for i := 0; i < 3; i++ {
+		if got := mustGetToken(t, z); got != "token-1" {
+			t.Fatalf("Get #%d = %q, want token-1", i, got)
+		}
+	}
+	if calls := server.calls.Load(); calls != 1 {
+		t.Errorf("token endpoint called %d times, want 1", calls)
+	}
+}
+
+func TestTokenManagerServesStaleTokenDuringBackgroundRefresh(t *testing.T) {
+	// 有效期一小时、提前两小时刷新，令牌始终处于刷新窗口内但远未过期
+	server := newOAuthServer(t, 3600, 2)
+	z := newTestZoomService(t, server.URL, 7200)
+
+	if got := mustGetToken(t, z); got != "token-1" {
+		t.Fatalf("first Get = %q, want token-1", got)
+	}
+	// 后台刷新被阻塞期间继续返回旧令牌，且只发起一次刷新
+	for i := 0; i < 3; i++ {
+		if got := mustGetToken(t, z); got != "token-1" {
+			t.Fatalf("Get during refresh = %q, want stale token-1", got)
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	if calls := server.calls.Load(); calls != 2 {
+		t.Errorf("token endpoint called %d times during refresh, want 2", calls)
+	}
+
+	close(server.gate)
+	if err := z.tokens.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if got := mustGetToken(t, z); got != "token-2" {
+		t.Errorf("Get after refresh = %q, want token-2", got)
+	}
+}
+
+func TestTokenManagerBlocksWhenTokenNearlyExpired(t *testing.T) {
+	// 有效期不足30秒的令牌不能再使用，每次都要同步刷新
+	server := newOAuthServer(t, 10, 0)
+	z := newTestZoomService(t, server.URL, 300)
+
+	if got := mustGetToken(t, z); got != "token-1" {
+		t.Fatalf("first Get = %q, want token-1", got)
+	}
+	if got := mustGetToken(t, z); got != "token-2" {
+		t.Errorf("second Get = %q, want token-2 from a blocking refresh", got)
+	}
+}
+
+func TestTokenManagerCoalescesConcurrentFetches(t *testing.T) {
+	server := newOAuthServer(t, 3600, 1)
+	z := newTestZoomService(t, server.URL, 300)
+
+	const n = 20
+	var wg sync.WaitGroup
+	tokens := make(chan string, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := z.GetOAuthToken(context.Background())
+			if err != nil {
+				t.Errorf("GetOAuthToken: %v", err)
+				return
+			}
+			tokens <- token.AccessToken
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(server.gate)
+	wg.Wait()
+	close(tokens)
+
+	for token := range tokens {
+		if token != "token-1" {
+			t.Errorf("got %q, want token-1", token)
+		}
+	}
+	if calls := server.calls.Load(); calls != 1 {
+		t.Errorf("token endpoint called %d times, want 1", calls)
+	}
+}
+
+func TestTokenManagerInvalidate(t *testing.T) {
+	server := newOAuthServer(t, 3600, 0)
+	z := newTestZoomService(t, server.URL, 300)
+
+	first := mustGetToken(t, z)
+	// 作废的不是当前令牌时不影响缓存
+	z.tokens.Invalidate("token-unknown")
+	if got := mustGetToken(t, z); got != first {
+		t.Errorf("Get after invalidating another token = %q, want %q", got, first)
+	}
+
+	z.tokens.Invalidate(first)
+	if got := mustGetToken(t, z); got != "token-2" {
+		t.Errorf("Get after Invalidate = %q, want token-2", got)
+	}
+	if calls := server.calls.Load(); calls != 2 {
+		t.Errorf("token endpoint called %d times, want 2", calls)
+	}
+}
+
+func TestTokenManagerWaitForRefreshOnShutdown(t *testing.T) {
+	server := newOAuthServer(t, 3600, 1)
+	z := newTestZoomService(t, server.URL, 300)
+
+	// 调用方取消后刷新仍在进行
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := z.GetOAuthToken(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetOAuthToken: err = %v, want deadline exceeded", err)
+	}
+
+	shortCtx, shortCancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer shortCancel()
+	if err := z.tokens.Wait(shortCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait during refresh: err = %v, want deadline exceeded", err)
+	}
+
+	close(server.gate)
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), time.Second)
+	defer waitCancel()
+	if err := z.Close(waitCtx); err != nil {
+		t.Errorf("Close after refresh finished: %v", err)
+	}
+	if got := mustGetToken(t, z); got != "token-1" {
+		t.Errorf("Get after shutdown wait = %q, want token-1 stored by the finished refresh", got)
+	}
+}
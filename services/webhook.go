@@ -0,0 +1,162 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"zoom-app-server/config"
+	"zoom-app-server/models"
+	"zoom-app-server/store"
+	"zoom-app-server/utils/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrWebhookSignatureInvalid 签名校验失败
+	ErrWebhookSignatureInvalid = errors.New("invalid webhook signature")
+	// ErrWebhookTimestampExpired 请求时间戳超出允许范围
+	ErrWebhookTimestampExpired = errors.New("webhook timestamp expired")
+	// ErrWebhookPayloadInvalid 事件内容无法解析，重发也不会成功
+	ErrWebhookPayloadInvalid = errors.New("invalid webhook payload")
+)
+
+// WebhookService Zoom Webhook 服务
+type WebhookService struct {
+	cfg          *config.Config
+	meetingStore *store.Store
+}
+
+// NewWebhookService 创建新的Webhook服务实例
+func NewWebhookService(cfg *config.Config, meetingStore *store.Store) *WebhookService {
+	return &WebhookService{
+		cfg:          cfg,
+		meetingStore: meetingStore,
+	}
+}
+
+// Enabled 是否配置了Webhook密钥
+func (s *WebhookService) Enabled() bool {
+	return s.cfg.ZoomWebhookSecretToken != ""
+}
+
+// VerifySignature 校验 x-zm-signature 与 x-zm-request-timestamp
+func (s *WebhookService) VerifySignature(signature, timestamp string, body []byte) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrWebhookSignatureInvalid
+	}
+
+	skew := time.Since(time.Unix(ts, 0))
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > time.Duration(s.cfg.ZoomWebhookMaxSkew)*time.Second {
+		return ErrWebhookTimestampExpired
+	}
+
+	expected := "v0=" + s.sign("v0:"+timestamp+":"+string(body))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrWebhookSignatureInvalid
+	}
+	return nil
+}
+
+// ValidateURL 生成 endpoint.url_validation 的应答
+func (s *WebhookService) ValidateURL(event *models.WebhookEvent) (*models.WebhookURLValidationResponse, error) {
+	var payload models.WebhookURLValidationPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return nil, err
+	}
+	if payload.PlainToken == "" {
+		return nil, errors.New("plainToken is required")
+	}
+
+	return &models.WebhookURLValidationResponse{
+		PlainToken:     payload.PlainToken,
+		EncryptedToken: s.sign(payload.PlainToken),
+	}, nil
+}
+
+// HandleEvent 解码并处理Webhook事件
+// 事件内容无效时返回包装了 ErrWebhookPayloadInvalid 的错误，其他错误（如写入数据库失败）可由Zoom重发后重试
+func (s *WebhookService) HandleEvent(event *models.WebhookEvent) error {
+	switch event.Event {
+	case models.WebhookEventMeetingStarted,
+		models.WebhookEventMeetingEnded,
+		models.WebhookEventParticipantJoined,
+		models.WebhookEventParticipantLeft:
+		var payload models.WebhookMeetingPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("%w: failed to decode %s payload: %v", ErrWebhookPayloadInvalid, event.Event, err)
+		}
+		return s.handleMeetingEvent(event.Event, &payload)
+	case models.WebhookEventRecordingCompleted:
+		var payload models.WebhookRecordingPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("%w: failed to decode %s payload: %v", ErrWebhookPayloadInvalid, event.Event, err)
+		}
+		return s.handleRecordingCompleted(&payload)
+	default:
+		logger.WithField("event", event.Event).Debug("Ignoring unsupported Zoom webhook event")
+		return nil
+	}
+}
+
+// handleMeetingEvent 处理会议及参会者事件
+func (s *WebhookService) handleMeetingEvent(eventType string, payload *models.WebhookMeetingPayload) error {
+	fields := logrus.Fields{
+		"event":      eventType,
+		"meeting_id": payload.Object.ID.String(),
+		"uuid":       payload.Object.UUID,
+		"topic":      payload.Object.Topic,
+	}
+	if p := payload.Object.Participant; p != nil {
+		fields["participant"] = p.UserName
+		fields["participant_email"] = p.Email
+	}
+	logger.WithFields(fields).Info("Received Zoom meeting event")
+
+	if eventType != models.WebhookEventMeetingStarted && eventType != models.WebhookEventMeetingEnded {
+		return nil
+	}
+
+	meetingID, err := payload.Object.ID.Int64()
+	if err != nil {
+		return fmt.Errorf("%w: invalid meeting id %q: %v", ErrWebhookPayloadInvalid, payload.Object.ID, err)
+	}
+	if eventType == models.WebhookEventMeetingStarted {
+		err = s.meetingStore.UpdateMeetingStatus(meetingID, models.MeetingStatusStarted)
+	} else {
+		// 定期会议结束的只是本次会议，由 EndMeeting 保持等待状态
+		err = s.meetingStore.EndMeeting(meetingID)
+	}
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// handleRecordingCompleted 处理录制完成事件
+func (s *WebhookService) handleRecordingCompleted(payload *models.WebhookRecordingPayload) error {
+	logger.WithFields(logrus.Fields{
+		"event":           models.WebhookEventRecordingCompleted,
+		"meeting_id":      payload.Object.ID.String(),
+		"uuid":            payload.Object.UUID,
+		"recording_count": payload.Object.RecordingCount,
+	}).Info("Received Zoom recording completed event")
+	return nil
+}
+
+// sign 使用Webhook密钥计算HMAC-SHA256，返回十六进制字符串
+func (s *WebhookService) sign(message string) string {
+	h := hmac.New(sha256.New, []byte(s.cfg.ZoomWebhookSecretToken))
+	h.Write([]byte(message))
+	return hex.EncodeToString(h.Sum(nil))
+}
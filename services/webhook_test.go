@@ -0,0 +1,146 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"zoom-app-server/config"
+	"zoom-app-server/models"
+	"zoom-app-server/store"
+)
+
+const testWebhookSecret = "webhook-secret"
+
+// newTestWebhookService 创建使用临时数据库的Webhook服务
+func newTestWebhookService(t *testing.T) (*WebhookService, *store.Store) {
+	t.Helper()
+	meetingStore, err := store.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { meetingStore.Close() })
+	return NewWebhookService(&config.Config{
+		ZoomWebhookSecretToken: testWebhookSecret,
+		ZoomWebhookMaxSkew:     300,
+	}, meetingStore), meetingStore
+}
+
+// zoomSignature 按Zoom的规则计算签名：v0=HMAC-SHA256("v0:{timestamp}:{body}")
+func zoomSignature(secret, timestamp string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte("v0:" + timestamp + ":" + string(body)))
+	return "v0=" + hex.EncodeToString(h.Sum(nil))
+}
+
+func TestWebhookVerifySignature(t *testing.T) {
+	s, _ := newTestWebhookService(t)
+	body := []byte(`{"event":"meeting.started","payload":{"object":{"id":"123"}}}`)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+	future := strconv.FormatInt(time.Now().Add(10*time.Minute).Unix(), 10)
+
+	tests := []struct {
+		name      string
+		signature string
+		timestamp string
+		body      []byte
+		wantErr   error
+	}{
+		{"valid signature", zoomSignature(testWebhookSecret, now, body), now, body, nil},
+		{"tampered body", zoomSignature(testWebhookSecret, now, body), now, []byte(`{"event":"meeting.ended"}`), ErrWebhookSignatureInvalid},
+		{"wrong secret", zoomSignature("other-secret", now, body), now, body, ErrWebhookSignatureInvalid},
+		{"signature for another timestamp", zoomSignature(testWebhookSecret, stale, body), now, body, ErrWebhookSignatureInvalid},
+		{"missing signature", "", now, body, ErrWebhookSignatureInvalid},
+		{"stale timestamp", zoomSignature(testWebhookSecret, stale, body), stale, body, ErrWebhookTimestampExpired},
+		{"future timestamp", zoomSignature(testWebhookSecret, future, body), future, body, ErrWebhookTimestampExpired},
+		{"malformed timestamp", zoomSignature(testWebhookSecret, "abc", body), "abc", body, ErrWebhookSignatureInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.VerifySignature(tt.signature, tt.timestamp, tt.body)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("VerifySignature() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookValidateURL(t *testing.T) {
+	s, _ := newTestWebhookService(t)
+
+	event := &models.WebhookEvent{
+		Event:   models.WebhookEventURLValidation,
+		Payload: json.RawMessage(`{"plainToken":"qgg8vlvZRS6UYooatFL8Aw"}`),
+	}
+	resp, err := s.ValidateURL(event)
+	if err != nil {
+		t.Fatalf("ValidateURL: %v", err)
+	}
+	h := hmac.New(sha256.New, []byte(testWebhookSecret))
+	h.Write([]byte("qgg8vlvZRS6UYooatFL8Aw"))
+	if resp.PlainToken != "qgg8vlvZRS6UYooatFL8Aw" || resp.EncryptedToken != hex.EncodeToString(h.Sum(nil)) {
+		t.Errorf("ValidateURL() = %+v", resp)
+	}
+
+	for _, payload := range []string{`{}`, `not json`} {
+		event.Payload = json.RawMessage(payload)
+		if _, err := s.ValidateURL(event); err == nil {
+			t.Errorf("ValidateURL(%s) succeeded, want error", payload)
+		}
+	}
+}
+
+func TestWebhookHandleEvent(t *testing.T) {
+	s, meetingStore := newTestWebhookService(t)
+	if err := meetingStore.SaveMeeting(&models.MeetingRecord{
+		ZoomMeetingID: 85000000001,
+		Type:          models.MeetingTypeScheduled,
+		Status:        models.MeetingStatusWaiting,
+	}); err != nil {
+		t.Fatalf("SaveMeeting: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		event      string
+		payload    string
+		wantErr    error
+		wantStatus string
+	}{
+		{"meeting started", models.WebhookEventMeetingStarted, `{"object":{"id":"85000000001"}}`, nil, models.MeetingStatusStarted},
+		{"meeting ended", models.WebhookEventMeetingEnded, `{"object":{"id":85000000001}}`, nil, models.MeetingStatusEnded},
+		{"unknown meeting ignored", models.WebhookEventMeetingStarted, `{"object":{"id":"1"}}`, nil, models.MeetingStatusEnded},
+		{"unsupported event ignored", "meeting.sharing_started", `not json`, nil, models.MeetingStatusEnded},
+		{"malformed payload", models.WebhookEventMeetingStarted, `not json`, ErrWebhookPayloadInvalid, models.MeetingStatusEnded},
+		{"invalid meeting id", models.WebhookEventMeetingEnded, `{"object":{"id":"abc"}}`, ErrWebhookPayloadInvalid, models.MeetingStatusEnded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.HandleEvent(&models.WebhookEvent{Event: tt.event, Payload: json.RawMessage(tt.payload)})
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("HandleEvent() = %v, want %v", err, tt.wantErr)
+			}
+			record, err := meetingStore.GetMeetingByZoomID(85000000001)
+			if err != nil {
+				t.Fatalf("GetMeetingByZoomID: %v", err)
+			}
+			if record.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", record.Status, tt.wantStatus)
+			}
+		})
+	}
+
+	// 数据库故障不是内容错误，由Zoom重发
+	meetingStore.Close()
+	err := s.HandleEvent(&models.WebhookEvent{Event: models.WebhookEventMeetingStarted, Payload: json.RawMessage(`{"object":{"id":"85000000001"}}`)})
+	if err == nil || errors.Is(err, ErrWebhookPayloadInvalid) {
+		t.Errorf("HandleEvent with closed store = %v, want internal error", err)
+	}
+}
@@ -2,35 +2,152 @@ package services
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"zoom-app-server/config"
+	"zoom-app-server/metrics"
 	"zoom-app-server/models"
+	"zoom-app-server/tracing"
+	"zoom-app-server/utils/logger"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Meeting SDK 签名与 Video SDK 会话令牌的有效期限制，exp 须在 iat 之后30分钟到48小时之间
+const (
+	minSignatureTTL = 30 * time.Minute
+	maxSignatureTTL = 48 * time.Hour
 )
 
+// ZoomClient Zoom服务接口，便于处理器在测试中替换为假实现
+type ZoomClient interface {
+	GenerateSignature(meetingNumber string, role int, videoWebRTCMode *int) (string, error)
+	GenerateVideoSDKToken(tokenReq *models.VideoSDKTokenRequest, userIdentity string) (string, int64, error)
+	GetOAuthToken(ctx context.Context) (*models.OAuthTokenResponse, error)
+	CreateMeeting(ctx context.Context, meetingReq *models.CreateMeetingRequest) (*models.CreateMeetingResponse, error)
+	GetMeeting(ctx context.Context, meetingID string) (*models.CreateMeetingResponse, error)
+	ListMeetings(ctx context.Context, listReq *models.ListMeetingsRequest) (*models.ListMeetingsResponse, error)
+	UpdateMeeting(ctx context.Context, meetingID string, updateReq *models.UpdateMeetingRequest) error
+	DeleteMeeting(ctx context.Context, meetingID string) error
+	UpdateMeetingStatus(ctx context.Context, meetingID string, statusReq *models.UpdateMeetingStatusRequest) error
+	ListOccurrences(ctx context.Context, meetingID string, showPrevious bool) (*models.ListOccurrencesResponse, error)
+	UpdateOccurrence(ctx context.Context, meetingID, occurrenceID string, updateReq *models.UpdateOccurrenceRequest) error
+	DeleteOccurrence(ctx context.Context, meetingID, occurrenceID string) error
+	GetZAKToken(ctx context.Context, zoomUserID string) (string, error)
+	GetOBFToken(ctx context.Context, zoomUserID, meetingNumber string) (string, error)
+}
+
+var _ ZoomClient = (*ZoomService)(nil)
+
 // ZoomService Zoom服务
 type ZoomService struct {
-	cfg *config.Config
+	cfg              *config.Config
+	httpClient       *zoomHTTPClient
+	tokens           *tokenManager
+	signatureTTL     time.Duration
+	videoSDKTokenTTL time.Duration
+	requestTimeout   time.Duration
 }
 
 // NewZoomService 创建新的Zoom服务实例
 func NewZoomService(cfg *config.Config) *ZoomService {
-	return &ZoomService{
-		cfg: cfg,
+	z := &ZoomService{
+		cfg:            cfg,
+		httpClient:     newZoomHTTPClient(cfg),
+		requestTimeout: time.Duration(cfg.ZoomRequestTimeout) * time.Second,
 	}
+	z.tokens = newTokenManager(z.fetchOAuthToken, time.Duration(cfg.ZoomTokenRefreshBefore)*time.Second, z.requestTimeout)
+	z.signatureTTL = clampSignatureTTL("ZOOM_SIGNATURE_TTL", time.Duration(cfg.ZoomSignatureTTL)*time.Second)
+	z.videoSDKTokenTTL = clampSignatureTTL("ZOOM_VIDEO_SDK_TOKEN_TTL", time.Duration(cfg.ZoomVideoSDKTokenTTL)*time.Second)
+	return z
 }
 
-// GenerateSignature 生成Zoom JWT签名
-func (z *ZoomService) GenerateSignature(meetingNumber string, role int) (string, error) {
+// Close 等待后台的OAuth令牌刷新结束并关闭空闲连接，用于服务退出
+func (z *ZoomService) Close(ctx context.Context) error {
+	err := z.tokens.Wait(ctx)
+	z.httpClient.client.CloseIdleConnections()
+	return err
+}
+
+// GenerateSignature 生成Zoom Meeting SDK签名
+// 根据配置使用 Meeting SDK 格式或旧版 JWT 应用格式
+func (z *ZoomService) GenerateSignature(meetingNumber string, role int, videoWebRTCMode *int) (string, error) {
+	if z.cfg.ZoomSignatureFormat == config.SignatureFormatLegacy {
+		return z.generateLegacySignature(meetingNumber, role)
+	}
+
+	// iat 向前偏移，避免客户端时钟略慢时签名尚未生效
+	iat := time.Now().Add(-time.Duration(z.cfg.ZoomSignatureClockSkew) * time.Second)
+	exp := iat.Add(z.signatureTTL).Unix()
+
+	payload := models.MeetingSDKPayload{
+		AppKey:          z.cfg.ZoomSDKKey,
+		SDKKey:          z.cfg.ZoomSDKKey,
+		Mn:              meetingNumber,
+		Role:            role,
+		Iat:             iat.Unix(),
+		Exp:             exp,
+		TokenExp:        exp,
+		VideoWebRTCMode: videoWebRTCMode,
+	}
+	return signJWT(payload, z.cfg.ZoomSDKSecret)
+}
+
+// GenerateVideoSDKToken 生成Zoom Video SDK会话令牌，返回令牌与过期时间
+func (z *ZoomService) GenerateVideoSDKToken(tokenReq *models.VideoSDKTokenRequest, userIdentity string) (string, int64, error) {
+	if z.cfg.ZoomVideoSDKKey == "" || z.cfg.ZoomVideoSDKSecret == "" {
+		return "", 0, errors.New("ZOOM_VIDEO_SDK_KEY and ZOOM_VIDEO_SDK_SECRET must be set")
+	}
+
+	iat := time.Now().Add(-time.Duration(z.cfg.ZoomSignatureClockSkew) * time.Second)
+	exp := iat.Add(z.videoSDKTokenTTL).Unix()
+
+	payload := models.VideoSDKPayload{
+		AppKey:               z.cfg.ZoomVideoSDKKey,
+		Tpc:                  tokenReq.SessionName,
+		RoleType:             tokenReq.Role,
+		SessionKey:           tokenReq.SessionKey,
+		UserIdentity:         userIdentity,
+		GeoRegions:           strings.Join(tokenReq.GeoRegions, ","),
+		CloudRecordingOption: tokenReq.CloudRecordingOption,
+		Version:              1,
+		Iat:                  iat.Unix(),
+		Exp:                  exp,
+	}
+	token, err := signJWT(payload, z.cfg.ZoomVideoSDKSecret)
+	if err != nil {
+		return "", 0, err
+	}
+	return token, exp, nil
+}
+
+// generateLegacySignature 生成旧版JWT应用格式的签名
+func (z *ZoomService) generateLegacySignature(meetingNumber string, role int) (string, error) {
+	payload := models.JWTPayload{
+		Iss:  z.cfg.ZoomAPIKey,
+		Exp:  time.Now().Add(time.Hour * 24).Unix(),
+		Mn:   meetingNumber,
+		Role: role,
+	}
+	return signJWT(payload, z.cfg.ZoomAPISecret)
+}
+
+// signJWT 使用HMAC SHA256生成JWT
+func signJWT(payload interface{}, secret string) (string, error) {
 	// 创建JWT头部
 	header := models.JWTHeader{
 		Alg: "HS256",
@@ -43,12 +160,6 @@ func (z *ZoomService) GenerateSignature(meetingNumber string, role int) (string,
 	headerBase64 := base64.RawURLEncoding.EncodeToString(headerJSON)
 
 	// 创建JWT负载
-	payload := models.JWTPayload{
-		Iss:  z.cfg.ZoomAPIKey,
-		Exp:  time.Now().Add(time.Hour * 24).Unix(),
-		Mn:   meetingNumber,
-		Role: role,
-	}
 	payloadJSON, err := json.Marshal(payload)
 	if err != nil {
 		return "", err
@@ -59,7 +170,7 @@ func (z *ZoomService) GenerateSignature(meetingNumber string, role int) (string,
 	message := headerBase64 + "." + payloadBase64
 
 	// 使用HMAC SHA256创建签名
-	h := hmac.New(sha256.New, []byte(z.cfg.ZoomAPISecret))
+	h := hmac.New(sha256.New, []byte(secret))
 	h.Write([]byte(message))
 	signature := base64.RawURLEncoding.EncodeToString(h.Sum(nil))
 
@@ -67,77 +178,370 @@ func (z *ZoomService) GenerateSignature(meetingNumber string, role int) (string,
 	return message + "." + signature, nil
 }
 
-// GetOAuthToken 获取OAuth访问令牌
-func (z *ZoomService) GetOAuthToken() (*models.OAuthTokenResponse, error) {
-	tokenURL := "https://zoom.us/oauth/token"
-	
+// clampSignatureTTL 将签名或令牌有效期限制在Zoom允许的范围内，name 为对应的配置项
+func clampSignatureTTL(name string, ttl time.Duration) time.Duration {
+	clamped := ttl
+	if clamped < minSignatureTTL {
+		clamped = minSignatureTTL
+	}
+	if clamped > maxSignatureTTL {
+		clamped = maxSignatureTTL
+	}
+	if clamped != ttl {
+		logger.WithFields(logrus.Fields{
+			"setting":    name,
+			"configured": ttl.String(),
+			"effective":  clamped.String(),
+		}).Warn("Signature TTL out of range, clamped to Zoom limits")
+	}
+	return clamped
+}
+
+// GetOAuthToken 获取OAuth访问令牌（优先使用缓存）
+func (z *ZoomService) GetOAuthToken(ctx context.Context) (*models.OAuthTokenResponse, error) {
+	return z.tokens.Get(ctx)
+}
+
+// fetchOAuthToken 向Zoom请求新的OAuth访问令牌
+func (z *ZoomService) fetchOAuthToken(ctx context.Context) (_ *models.OAuthTokenResponse, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "Zoom OAuth token fetch", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	data := url.Values{}
 	data.Set("grant_type", "account_credentials")
 	data.Set("account_id", z.cfg.ZoomAccountID)
-	
-	req, err := http.NewRequest("POST", tokenURL, strings.NewReader(data.Encode()))
-	if err != nil {
-		return nil, err
-	}
-	
+
 	// 设置Basic Auth
 	auth := base64.StdEncoding.EncodeToString([]byte(z.cfg.ZoomClientID + ":" + z.cfg.ZoomClientSecret))
-	req.Header.Set("Authorization", "Basic "+auth)
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := z.httpClient.Do(RateCategoryNone, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", strings.TrimRight(z.cfg.ZoomOAuthBaseURL, "/")+"/oauth/token", strings.NewReader(data.Encode()))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Basic "+auth)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
-	
+
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get OAuth token: %s, response: %s", resp.Status, string(body))
+		return nil, newZoomAPIError(resp, ActionGetOAuthToken)
 	}
-	
+
 	var tokenResp models.OAuthTokenResponse
 	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
 		return nil, err
 	}
-	
+
 	return &tokenResp, nil
 }
 
-// CreateMeeting 创建Zoom会议
-func (z *ZoomService) CreateMeeting(accessToken string, meetingReq *models.CreateMeetingRequest) (*models.CreateMeetingResponse, error) {
-	createURL := "https://api.zoom.us/v2/users/me/meetings"
-	
-	jsonData, err := json.Marshal(meetingReq)
+// doAPIRequest 使用OAuth令牌调用Zoom REST API
+// 整个调用（含重试）受 requestTimeout 限制，期限和追踪 span 在响应体关闭时释放；收到401时作废当前令牌并重试一次
+func (z *ZoomService) doAPIRequest(ctx context.Context, category RateCategory, method, path string, body interface{}) (*http.Response, error) {
+	var payload []byte
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		payload = data
+	}
+
+	endpoint, _, _ := strings.Cut(path, "?")
+	endpoint = metrics.ZoomEndpoint(endpoint)
+	ctx, span := tracing.Tracer().Start(ctx, "Zoom "+method+" "+endpoint,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", method),
+			attribute.String("zoom.endpoint", endpoint),
+		),
+	)
+	if meetingID := meetingIDFromPath(path); meetingID != "" {
+		span.SetAttributes(tracing.AttrMeetingID.String(meetingID))
+	}
+
+	cancel := context.CancelFunc(func() {})
+	if z.requestTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, z.requestTimeout)
+	}
+	resp, err := z.sendAPIRequest(ctx, category, method, path, body != nil, payload)
 	if err != nil {
+		cancel()
+		tracing.EndSpan(span, err)
 		return nil, err
 	}
-	
-	req, err := http.NewRequest("POST", createURL, bytes.NewBuffer(jsonData))
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: func() {
+		cancel()
+		span.End()
+	}}
+	return resp, nil
+}
+
+// meetingIDFromPath 从请求路径中提取会议ID
+func meetingIDFromPath(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if segments[i] == "meetings" {
+			return segments[i+1]
+		}
+	}
+	return ""
+}
+
+// sendAPIRequest 发送请求，收到401时作废当前令牌并重试一次
+func (z *ZoomService) sendAPIRequest(ctx context.Context, category RateCategory, method, path string, hasBody bool, payload []byte) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		token, err := z.tokens.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := z.httpClient.Do(category, func() (*http.Request, error) {
+			req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(z.cfg.ZoomAPIBaseURL, "/")+path, bytes.NewReader(payload))
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+			if hasBody {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			return req, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusUnauthorized && attempt == 0 {
+			resp.Body.Close()
+			logger.WithField("path", path).Warn("Zoom API returned 401, invalidating cached OAuth token")
+			z.tokens.Invalidate(token.AccessToken)
+			continue
+		}
+		return resp, nil
+	}
+}
+
+// releaseOnClose 在响应体关闭时释放请求的期限并结束 span
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+// Close 关闭响应体并释放资源
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// readAPIResponse 检查响应状态码并解码响应体
+func readAPIResponse(resp *http.Response, expectedStatus int, action string, out interface{}) error {
+	defer resp.Body.Close()
+
+	if resp.StatusCode != expectedStatus {
+		return newZoomAPIError(resp, action)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// CreateMeeting 创建Zoom会议
+func (z *ZoomService) CreateMeeting(ctx context.Context, meetingReq *models.CreateMeetingRequest) (*models.CreateMeetingResponse, error) {
+	resp, err := z.doAPIRequest(ctx, RateCategoryMedium, "POST", "/users/me/meetings", meetingReq)
 	if err != nil {
 		return nil, err
 	}
-	
-	req.Header.Set("Authorization", "Bearer "+accessToken)
-	req.Header.Set("Content-Type", "application/json")
-	
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
+
+	var meetingResp models.CreateMeetingResponse
+	if err := readAPIResponse(resp, http.StatusCreated, "create meeting", &meetingResp); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	
-	if resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to create meeting: %s, response: %s", resp.Status, string(body))
+
+	return &meetingResp, nil
+}
+
+// GetMeeting 获取会议详情
+func (z *ZoomService) GetMeeting(ctx context.Context, meetingID string) (*models.CreateMeetingResponse, error) {
+	return z.getMeeting(ctx, meetingID, nil)
+}
+
+// getMeeting 获取会议详情，可附带查询参数
+func (z *ZoomService) getMeeting(ctx context.Context, meetingID string, query url.Values) (*models.CreateMeetingResponse, error) {
+	path := "/meetings/" + url.PathEscape(meetingID)
+	if len(query) > 0 {
+		path += "?" + query.Encode()
 	}
-	
+
+	resp, err := z.doAPIRequest(ctx, RateCategoryLight, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	var meetingResp models.CreateMeetingResponse
-	if err := json.NewDecoder(resp.Body).Decode(&meetingResp); err != nil {
+	if err := readAPIResponse(resp, http.StatusOK, "get meeting", &meetingResp); err != nil {
 		return nil, err
 	}
-	
+
 	return &meetingResp, nil
-}
\ No newline at end of file
+}
+
+// ListMeetings 获取当前账号的会议列表
+func (z *ZoomService) ListMeetings(ctx context.Context, listReq *models.ListMeetingsRequest) (*models.ListMeetingsResponse, error) {
+	query := url.Values{}
+	if listReq.Type != "" {
+		query.Set("type", listReq.Type)
+	}
+	if listReq.PageSize > 0 {
+		query.Set("page_size", strconv.Itoa(listReq.PageSize))
+	}
+	if listReq.PageNumber > 0 {
+		query.Set("page_number", strconv.Itoa(listReq.PageNumber))
+	}
+	if listReq.NextPageToken != "" {
+		query.Set("next_page_token", listReq.NextPageToken)
+	}
+	if listReq.From != "" {
+		query.Set("from", listReq.From)
+	}
+	if listReq.To != "" {
+		query.Set("to", listReq.To)
+	}
+
+	path := "/users/me/meetings"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	resp, err := z.doAPIRequest(ctx, RateCategoryMedium, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var listResp models.ListMeetingsResponse
+	if err := readAPIResponse(resp, http.StatusOK, "list meetings", &listResp); err != nil {
+		return nil, err
+	}
+
+	return &listResp, nil
+}
+
+// UpdateMeeting 更新会议
+func (z *ZoomService) UpdateMeeting(ctx context.Context, meetingID string, updateReq *models.UpdateMeetingRequest) error {
+	resp, err := z.doAPIRequest(ctx, RateCategoryLight, "PATCH", "/meetings/"+url.PathEscape(meetingID), updateReq)
+	if err != nil {
+		return err
+	}
+
+	return readAPIResponse(resp, http.StatusNoContent, "update meeting", nil)
+}
+
+// DeleteMeeting 删除会议
+func (z *ZoomService) DeleteMeeting(ctx context.Context, meetingID string) error {
+	resp, err := z.doAPIRequest(ctx, RateCategoryLight, "DELETE", "/meetings/"+url.PathEscape(meetingID), nil)
+	if err != nil {
+		return err
+	}
+
+	return readAPIResponse(resp, http.StatusNoContent, "delete meeting", nil)
+}
+
+// UpdateMeetingStatus 更新会议状态（结束进行中的会议）
+func (z *ZoomService) UpdateMeetingStatus(ctx context.Context, meetingID string, statusReq *models.UpdateMeetingStatusRequest) error {
+	resp, err := z.doAPIRequest(ctx, RateCategoryLight, "PUT", "/meetings/"+url.PathEscape(meetingID)+"/status", statusReq)
+	if err != nil {
+		return err
+	}
+
+	return readAPIResponse(resp, http.StatusNoContent, "update meeting status", nil)
+}
+
+// ListOccurrences 获取定期会议的单次会议列表
+func (z *ZoomService) ListOccurrences(ctx context.Context, meetingID string, showPrevious bool) (*models.ListOccurrencesResponse, error) {
+	query := url.Values{}
+	if showPrevious {
+		query.Set("show_previous_occurrences", "true")
+	}
+
+	meeting, err := z.getMeeting(ctx, meetingID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences := meeting.Occurrences
+	if occurrences == nil {
+		occurrences = []models.Occurrence{}
+	}
+	return &models.ListOccurrencesResponse{
+		MeetingID:   meeting.ID,
+		Topic:       meeting.Topic,
+		Type:        meeting.Type,
+		Recurrence:  meeting.Recurrence,
+		Occurrences: occurrences,
+	}, nil
+}
+
+// UpdateOccurrence 更新定期会议中的单次会议
+func (z *ZoomService) UpdateOccurrence(ctx context.Context, meetingID, occurrenceID string, updateReq *models.UpdateOccurrenceRequest) error {
+	query := url.Values{}
+	query.Set("occurrence_id", occurrenceID)
+
+	resp, err := z.doAPIRequest(ctx, RateCategoryLight, "PATCH", "/meetings/"+url.PathEscape(meetingID)+"?"+query.Encode(), updateReq)
+	if err != nil {
+		return err
+	}
+
+	return readAPIResponse(resp, http.StatusNoContent, "update occurrence", nil)
+}
+
+// DeleteOccurrence 删除定期会议中的单次会议
+func (z *ZoomService) DeleteOccurrence(ctx context.Context, meetingID, occurrenceID string) error {
+	query := url.Values{}
+	query.Set("occurrence_id", occurrenceID)
+
+	resp, err := z.doAPIRequest(ctx, RateCategoryLight, "DELETE", "/meetings/"+url.PathEscape(meetingID)+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	return readAPIResponse(resp, http.StatusNoContent, "delete occurrence", nil)
+}
+
+// GetZAKToken 获取用户的ZAK令牌，用于以主持人身份开始会议
+func (z *ZoomService) GetZAKToken(ctx context.Context, zoomUserID string) (string, error) {
+	return z.getUserToken(ctx, zoomUserID, models.UserTokenTypeZAK, "")
+}
+
+// GetOBFToken 获取用户的OBF令牌，用于代表用户加入其他账号的会议
+func (z *ZoomService) GetOBFToken(ctx context.Context, zoomUserID, meetingNumber string) (string, error) {
+	return z.getUserToken(ctx, zoomUserID, models.UserTokenTypeOBF, meetingNumber)
+}
+
+// getUserToken 获取指定类型的用户令牌
+func (z *ZoomService) getUserToken(ctx context.Context, zoomUserID, tokenType, meetingNumber string) (string, error) {
+	query := url.Values{}
+	query.Set("type", tokenType)
+	if meetingNumber != "" {
+		query.Set("meeting_id", meetingNumber)
+	}
+
+	resp, err := z.doAPIRequest(ctx, RateCategoryLight, "GET", "/users/"+url.PathEscape(zoomUserID)+"/token?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	var tokenResp models.ZoomUserTokenResponse
+	if err := readAPIResponse(resp, http.StatusOK, "get "+tokenType+" token", &tokenResp); err != nil {
+		return "", err
+	}
+
+	return tokenResp.Token, nil
+}
@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"zoom-app-server/config"
+	"zoom-app-server/metrics"
+	"zoom-app-server/tracing"
+	"zoom-app-server/utils/logger"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+// RateCategory Zoom API 限流类别
+type RateCategory string
+
+const (
+	RateCategoryNone   RateCategory = ""       // 不做客户端限流（如OAuth令牌接口）
+	RateCategoryLight  RateCategory = "light"  // 轻量接口
+	RateCategoryMedium RateCategory = "medium" // 中等接口
+	RateCategoryHeavy  RateCategory = "heavy"  // 重量接口
+)
+
+// 重试退避参数
+const (
+	retryBaseDelay = 200 * time.Millisecond
+	retryMaxDelay  = 5 * time.Second
+)
+
+// ErrZoomRateLimited Zoom返回429且无法在允许的等待时间内重试
+var ErrZoomRateLimited = errors.New("zoom api rate limited")
+
+// zoomHTTPClient 调用Zoom的共享HTTP客户端
+// 复用连接池，按类别做客户端限流，对网络错误、5xx和429进行带抖动的指数退避重试
+type zoomHTTPClient struct {
+	client       *http.Client
+	maxRetries   int
+	maxRetryWait time.Duration
+	limiters     map[RateCategory]*rate.Limiter
+
+	mu           sync.Mutex
+	blockedUntil map[RateCategory]time.Time
+}
+
+// newZoomHTTPClient 创建Zoom HTTP客户端
+func newZoomHTTPClient(cfg *config.Config) *zoomHTTPClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 100
+	transport.MaxIdleConnsPerHost = 20
+	transport.IdleConnTimeout = 90 * time.Second
+
+	return &zoomHTTPClient{
+		client: &http.Client{
+			Timeout:   time.Duration(cfg.ZoomHTTPTimeout) * time.Second,
+			Transport: tracing.Transport(transport),
+		},
+		maxRetries:   cfg.ZoomMaxRetries,
+		maxRetryWait: time.Duration(cfg.ZoomRetryMaxWait) * time.Second,
+		limiters: map[RateCategory]*rate.Limiter{
+			RateCategoryLight:  newCategoryLimiter(cfg.ZoomRateLimitLight),
+			RateCategoryMedium: newCategoryLimiter(cfg.ZoomRateLimitMedium),
+			RateCategoryHeavy:  newCategoryLimiter(cfg.ZoomRateLimitHeavy),
+		},
+		blockedUntil: make(map[RateCategory]time.Time),
+	}
+}
+
+// newCategoryLimiter 创建每秒perSecond次的限流器，perSecond<=0表示不限流
+func newCategoryLimiter(perSecond int) *rate.Limiter {
+	if perSecond <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	return rate.NewLimiter(rate.Limit(perSecond), perSecond)
+}
+
+// Do 发送请求，newReq 每次重试都会被调用以重新构建请求体
+func (c *zoomHTTPClient) Do(category RateCategory, newReq func() (*http.Request, error)) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := newReq()
+		if err != nil {
+			return nil, err
+		}
+		if err := c.wait(req.Context(), category); err != nil {
+			return nil, err
+		}
+
+		start := time.Now()
+		resp, err := c.client.Do(req)
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		metrics.ObserveZoomRequest(req.Method, req.URL.Path, status, time.Since(start))
+		retryable, delay := c.shouldRetry(req, resp, err, attempt)
+		if !retryable {
+			if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+				c.block(category, delay)
+				return resp, nil
+			}
+			return resp, err
+		}
+
+		fields := logrus.Fields{
+			"method":  req.Method,
+			"path":    req.URL.Path,
+			"attempt": attempt + 1,
+			"delay":   delay.String(),
+		}
+		if err != nil {
+			logger.WithError(err).WithFields(fields).Warn("Zoom request failed, retrying")
+		} else {
+			fields["status"] = resp.StatusCode
+			if resp.StatusCode == http.StatusTooManyRequests {
+				fields["rate_limit_category"] = resp.Header.Get("X-RateLimit-Category")
+				fields["rate_limit_type"] = resp.Header.Get("X-RateLimit-Type")
+				c.block(category, delay)
+			}
+			logger.WithFields(fields).Warn("Zoom request returned retryable status, retrying")
+			resp.Body.Close()
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// shouldRetry 判断是否需要重试以及等待时间
+// POST 不是幂等操作，只在429和503（请求未被处理）时重试，避免重复创建会议
+func (c *zoomHTTPClient) shouldRetry(req *http.Request, resp *http.Response, err error, attempt int) (bool, time.Duration) {
+	if err != nil {
+		if req.Context().Err() != nil || attempt >= c.maxRetries {
+			return false, 0
+		}
+		// POST 只在确认请求没有发出（建立连接失败）时重试
+		if req.Method == http.MethodPost && !isDialError(err) {
+			return false, 0
+		}
+		return true, backoff(attempt)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		delay, ok := retryAfter(resp)
+		// 每日配额耗尽时重试没有意义
+		if strings.Contains(strings.ToLower(resp.Header.Get("X-RateLimit-Type")), "daily") {
+			return false, delay
+		}
+		if !ok {
+			delay = backoff(attempt)
+		}
+		if attempt >= c.maxRetries || delay > c.maxRetryWait {
+			return false, delay
+		}
+		return true, delay
+	case resp.StatusCode >= 500:
+		if attempt >= c.maxRetries {
+			return false, 0
+		}
+		if req.Method == http.MethodPost && resp.StatusCode != http.StatusServiceUnavailable {
+			return false, 0
+		}
+		if delay, ok := retryAfter(resp); ok && delay <= c.maxRetryWait {
+			return true, delay
+		}
+		return true, backoff(attempt)
+	}
+
+	logRateLimitHeaders(req, resp)
+	return false, 0
+}
+
+// wait 等待限流器放行；若该类别因429被暂停，则先等待暂停结束
+func (c *zoomHTTPClient) wait(ctx context.Context, category RateCategory) error {
+	if category == RateCategoryNone {
+		return nil
+	}
+
+	c.mu.Lock()
+	until := c.blockedUntil[category]
+	c.mu.Unlock()
+	if d := time.Until(until); d > 0 {
+		if d > c.maxRetryWait {
+			return fmt.Errorf("%w: category %s blocked for %s", ErrZoomRateLimited, category, d.Round(time.Second))
+		}
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if limiter, ok := c.limiters[category]; ok {
+		return limiter.Wait(ctx)
+	}
+	return nil
+}
+
+// block 在收到429后暂停该类别的请求
+func (c *zoomHTTPClient) block(category RateCategory, d time.Duration) {
+	if category == RateCategoryNone || d <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	until := time.Now().Add(d)
+	if until.After(c.blockedUntil[category]) {
+		c.blockedUntil[category] = until
+	}
+}
+
+// backoff 带抖动的指数退避
+func backoff(attempt int) time.Duration {
+	delay := retryBaseDelay << uint(attempt)
+	if delay > retryMaxDelay || delay <= 0 {
+		delay = retryMaxDelay
+	}
+	// 在 [delay/2, delay) 之间随机
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)))
+}
+
+// retryAfter 解析 Retry-After 响应头（秒数或HTTP日期）
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return time.Until(t), true
+	}
+	return 0, false
+}
+
+// logRateLimitHeaders 剩余配额较少时记录Zoom限流响应头
+func logRateLimitHeaders(req *http.Request, resp *http.Response) {
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	limit, _ := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	if limit > 0 && remaining > limit/10 {
+		return
+	}
+	logger.WithFields(logrus.Fields{
+		"path":                 req.URL.Path,
+		"rate_limit_category":  resp.Header.Get("X-RateLimit-Category"),
+		"rate_limit_type":      resp.Header.Get("X-RateLimit-Type"),
+		"rate_limit_limit":     limit,
+		"rate_limit_remaining": remaining,
+	}).Warn("Zoom API rate limit nearly exhausted")
+}
+
+// isDialError 建立连接失败时请求一定没有发出，可以安全重试
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"zoom-app-server/config"
+)
+
+// scriptedResponse 测试服务器按顺序返回的响应
+type scriptedResponse struct {
+	status int
+	header map[string]string
+}
+
+// newScriptedServer 按顺序返回 responses，超出后重复最后一个，calls 记录收到的请求数
+func newScriptedServer(t *testing.T, responses ...scriptedResponse) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		if n > len(responses) {
+			n = len(responses)
+		}
+		resp := responses[n-1]
+		for k, v := range resp.header {
+			w.Header().Set(k, v)
+		}
+		w.WriteHeader(resp.status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+// newTestHTTPClient 最多重试2次，429最多等待1秒
+func newTestHTTPClient() *zoomHTTPClient {
+	return newZoomHTTPClient(&config.Config{
+		ZoomHTTPTimeout:  5,
+		ZoomMaxRetries:   2,
+		ZoomRetryMaxWait: 1,
+	})
+}
+
+// requestFunc 返回每次重试都重新构建请求的函数，built 记录构建次数
+func requestFunc(method, url string, built *atomic.Int32) func() (*http.Request, error) {
+	return func() (*http.Request, error) {
+		if built != nil {
+			built.Add(1)
+		}
+		return http.NewRequest(method, url, strings.NewReader(`{}`))
+	}
+}
+
+func TestZoomHTTPClientRetries(t *testing.T) {
+	ok := scriptedResponse{status: http.StatusOK}
+	retryNow := map[string]string{"Retry-After": "0"}
+
+	tests := []struct {
+		name       string
+		method     string
+		responses  []scriptedResponse
+		wantStatus int
+		wantCalls  int32
+	}{
+		{"success", "GET", []scriptedResponse{ok}, 200, 1},
+		{"GET retried on 500", "GET", []scriptedResponse{{status: 500, header: retryNow}, ok}, 200, 2},
+		{"GET gives up after max retries", "GET", []scriptedResponse{{status: 502, header: retryNow}}, 502, 3},
+		{"POST not retried on 500", "POST", []scriptedResponse{{status: 500, header: retryNow}, ok}, 500, 1},
+		{"POST retried on 503", "POST", []scriptedResponse{{status: 503, header: retryNow}, ok}, 200, 2},
+		{"429 retried after Retry-After", "GET", []scriptedResponse{{status: 429, header: retryNow}, ok}, 200, 2},
+		{"POST retried on 429", "POST", []scriptedResponse{{status: 429, header: retryNow}, ok}, 200, 2},
+		{"daily limit not retried", "GET", []scriptedResponse{
+			{status: 429, header: map[string]string{"Retry-After": "0", "X-RateLimit-Type": "Daily-limit"}}, ok,
+		}, 429, 1},
+		{"Retry-After longer than max wait not retried", "GET", []scriptedResponse{
+			{status: 429, header: map[string]string{"Retry-After": "5"}}, ok,
+		}, 429, 1},
+		{"4xx not retried", "GET", []scriptedResponse{{status: 404}, ok}, 404, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := newScriptedServer(t, tt.responses...)
+			client := newTestHTTPClient()
+
+			resp, err := client.Do(RateCategoryLight, requestFunc(tt.method, server.URL, nil))
+			if err != nil {
+				t.Fatalf("Do: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("server called %d times, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestZoomHTTPClientBlocksCategoryAfter429(t *testing.T) {
+	server, calls := newScriptedServer(t,
+		scriptedResponse{status: 429, header: map[string]string{"Retry-After": "5"}},
+		scriptedResponse{status: http.StatusOK},
+	)
+	client := newTestHTTPClient()
+
+	resp, err := client.Do(RateCategoryLight, requestFunc("GET", server.URL, nil))
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	resp.Body.Close()
+
+	// 同一类别在暂停期间直接失败，不再请求Zoom
+	if _, err := client.Do(RateCategoryLight, requestFunc("GET", server.URL, nil)); !errors.Is(err, ErrZoomRateLimited) {
+		t.Errorf("Do on blocked category: err = %v, want ErrZoomRateLimited", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server called %d times, want 1", got)
+	}
+
+	// 其他类别不受影响
+	resp, err = client.Do(RateCategoryMedium, requestFunc("GET", server.URL, nil))
+	if err != nil {
+		t.Fatalf("Do on other category: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("other category status = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestZoomHTTPClientNetworkErrors(t *testing.T) {
+	// 建立连接失败，请求一定没有发出，POST 也可以重试
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	closedURL := "http://" + listener.Addr().String()
+	listener.Close()
+
+	// 连接建立后被断开，POST 可能已被处理，不能重试
+	var dropped atomic.Int32
+	dropServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dropped.Add(1)
+		io.Copy(io.Discard, r.Body)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	}))
+	defer dropServer.Close()
+
+	tests := []struct {
+		name      string
+		method    string
+		url       string
+		wantBuilt int32
+	}{
+		{"POST retried on dial error", "POST", closedURL, 3},
+		{"GET retried on dropped connection", "GET", dropServer.URL, 3},
+		{"POST not retried on dropped connection", "POST", dropServer.URL, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var built atomic.Int32
+			client := newTestHTTPClient()
+			if _, err := client.Do(RateCategoryLight, requestFunc(tt.method, tt.url, &built)); err == nil {
+				t.Fatal("Do succeeded, want error")
+			}
+			if got := built.Load(); got != tt.wantBuilt {
+				t.Errorf("request sent %d times, want %d", got, tt.wantBuilt)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		value  string
+		wantOK bool
+		min    time.Duration
+		max    time.Duration
+	}{
+		{value: "", wantOK: false},
+		{value: "3", wantOK: true, min: 3 * time.Second, max: 3 * time.Second},
+		{value: time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat), wantOK: true, min: 8 * time.Second, max: 10 * time.Second},
+		{value: "soon", wantOK: false},
+	}
+	for _, tt := range tests {
+		resp := &http.Response{Header: http.Header{}}
+		if tt.value != "" {
+			resp.Header.Set("Retry-After", tt.value)
+		}
+		got, ok := retryAfter(resp)
+		if ok != tt.wantOK || (ok && (got < tt.min || got > tt.max)) {
+			t.Errorf("retryAfter(%q) = %s, %v; want [%s, %s], %v", tt.value, got, ok, tt.min, tt.max, tt.wantOK)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt := 0; attempt < 10; attempt++ {
+		delay := retryBaseDelay << uint(attempt)
+		if delay > retryMaxDelay {
+			delay = retryMaxDelay
+		}
+		got := backoff(attempt)
+		if got < delay/2 || got >= delay {
+			t.Errorf("backoff(%d) = %s, want in [%s, %s)", attempt, got, delay/2, delay)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"zoom-app-server/models"
+)
+
+// Zoom API 常见错误码
+const (
+	ZoomCodeInvalidToken    = 124  // 访问令牌无效或已过期
+	ZoomCodeInvalidField    = 300  // 请求字段无效
+	ZoomCodeUserNotFound    = 1001 // 用户不存在
+	ZoomCodeMeetingNotFound = 3001 // 会议不存在或已过期
+	ZoomCodeRateLimited     = 429  // 请求过于频繁
+)
+
+// ActionGetOAuthToken 获取OAuth令牌的操作名，该操作失败说明服务端凭据有问题
+const ActionGetOAuthToken = "get OAuth token"
+
+// ZoomAPIError Zoom API 返回的错误
+type ZoomAPIError struct {
+	StatusCode int    // HTTP状态码
+	Action     string // 调用的操作，如 create meeting
+	models.ZoomErrorResponse
+}
+
+// Error 实现error接口
+func (e *ZoomAPIError) Error() string {
+	return fmt.Sprintf("failed to %s: status %d, code %d, message: %s", e.Action, e.StatusCode, e.Code, e.Message)
+}
+
+// newZoomAPIError 从Zoom错误响应构建ZoomAPIError
+func newZoomAPIError(resp *http.Response, action string) *ZoomAPIError {
+	apiErr := &ZoomAPIError{
+		StatusCode: resp.StatusCode,
+		Action:     action,
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err := json.Unmarshal(body, &apiErr.ZoomErrorResponse); err != nil || apiErr.Message == "" {
+		// OAuth 接口使用 {"reason": "...", "error": "..."} 格式
+		var oauthErr struct {
+			Reason string `json:"reason"`
+			Error  string `json:"error"`
+		}
+		if json.Unmarshal(body, &oauthErr) == nil && (oauthErr.Reason != "" || oauthErr.Error != "") {
+			apiErr.Message = oauthErr.Reason
+			if apiErr.Message == "" {
+				apiErr.Message = oauthErr.Error
+			}
+		} else if apiErr.Message == "" {
+			apiErr.Message = resp.Status
+		}
+	}
+	if apiErr.Code == 0 && resp.StatusCode == http.StatusTooManyRequests {
+		apiErr.Code = ZoomCodeRateLimited
+	}
+	return apiErr
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"zoom-app-server/config"
+	"zoom-app-server/models"
+)
+
+// decodeJWTPayload 解析JWT的负载部分
+func decodeJWTPayload(t *testing.T, token string, out interface{}) {
+	t.Helper()
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("token has %d parts, want 3", len(parts))
+	}
+	data, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		t.Fatalf("unmarshal payload: %v", err)
+	}
+}
+
+func TestGenerateVideoSDKTokenTTL(t *testing.T) {
+	tests := []struct {
+		name    string
+		ttl     int
+		wantTTL time.Duration
+	}{
+		{"default", 7200, 2 * time.Hour},
+		{"below minimum", 60, minSignatureTTL},
+		{"above maximum", 7 * 24 * 3600, maxSignatureTTL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			z := NewZoomService(&config.Config{
+				ZoomVideoSDKKey:        "key",
+				ZoomVideoSDKSecret:     "secret",
+				ZoomVideoSDKTokenTTL:   tt.ttl,
+				ZoomSignatureTTL:       7200,
+				ZoomSignatureClockSkew: 30,
+			})
+
+			token, expiresAt, err := z.GenerateVideoSDKToken(&models.VideoSDKTokenRequest{SessionName: "review"}, "1")
+			if err != nil {
+				t.Fatalf("GenerateVideoSDKToken: %v", err)
+			}
+			var payload models.VideoSDKPayload
+			decodeJWTPayload(t, token, &payload)
+			if got := time.Duration(payload.Exp-payload.Iat) * time.Second; got != tt.wantTTL {
+				t.Errorf("exp - iat = %s, want %s", got, tt.wantTTL)
+			}
+			if payload.Exp != expiresAt {
+				t.Errorf("returned expiry %d, payload exp %d", expiresAt, payload.Exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"zoom-app-server/models"
+)
+
+// meetingColumns meetings表查询字段
+const meetingColumns = `id, zoom_meeting_id, uuid, creator_user_id, host_id, host_email, topic, type,
+	start_time, duration, timezone, join_url, settings, recurrence, status, created_at, updated_at`
+
+// SaveMeeting 保存会议记录，Zoom会议号已存在时覆盖
+func (s *Store) SaveMeeting(rec *models.MeetingRecord) error {
+	now := time.Now()
+	if rec.CreatedAt.IsZero() {
+		rec.CreatedAt = now
+	}
+	rec.UpdatedAt = now
+	if rec.Status == "" {
+		rec.Status = models.MeetingStatusWaiting
+	}
+
+	settings, err := marshalNullable(rec.Settings)
+	if err != nil {
+		return err
+	}
+	recurrence, err := marshalNullable(rec.Recurrence)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+INSERT INTO meetings (zoom_meeting_id, uuid, creator_user_id, host_id, host_email, topic, type,
+	start_time, duration, timezone, join_url, settings, recurrence, status, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (zoom_meeting_id) DO UPDATE SET
+	uuid = excluded.uuid,
+	creator_user_id = excluded.creator_user_id,
+	host_id = excluded.host_id,
+	host_email = excluded.host_email,
+	topic = excluded.topic,
+	type = excluded.type,
+	start_time = excluded.start_time,
+	duration = excluded.duration,
+	timezone = excluded.timezone,
+	join_url = excluded.join_url,
+	settings = excluded.settings,
+	recurrence = excluded.recurrence,
+	status = excluded.status,
+	updated_at = excluded.updated_at
+RETURNING id`,
+		rec.ZoomMeetingID, rec.UUID, rec.CreatorUserID, rec.HostID, rec.HostEmail, rec.Topic, rec.Type,
+		unixOrNull(rec.StartTime), rec.Duration, rec.Timezone, rec.JoinURL, settings, recurrence, rec.Status,
+		rec.CreatedAt.Unix(), rec.UpdatedAt.Unix(),
+	).Scan(&rec.ID)
+	if err != nil {
+		return err
+	}
+
+	// 重新写入受邀参会者
+	if _, err := tx.Exec(`DELETE FROM meeting_invitees WHERE meeting_id = ?`, rec.ID); err != nil {
+		return err
+	}
+	if rec.Settings != nil {
+		for _, invitee := range rec.Settings.MeetingInvitees {
+			email := strings.ToLower(strings.TrimSpace(invitee.Email))
+			if email == "" {
+				continue
+			}
+			if _, err := tx.Exec(`INSERT OR IGNORE INTO meeting_invitees (meeting_id, email) VALUES (?, ?)`, rec.ID, email); err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetMeetingByZoomID 根据Zoom会议号获取会议记录
+func (s *Store) GetMeetingByZoomID(zoomMeetingID int64) (*models.MeetingRecord, error) {
+	row := s.db.QueryRow(`SELECT `+meetingColumns+` FROM meetings WHERE zoom_meeting_id = ?`, zoomMeetingID)
+	rec, err := scanMeeting(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return rec, err
+}
+
+// UpdateMeetingStatus 更新会议状态
+func (s *Store) UpdateMeetingStatus(zoomMeetingID int64, status string) error {
+	result, err := s.db.Exec(`UPDATE meetings SET status = ?, updated_at = ? WHERE zoom_meeting_id = ?`,
+		status, time.Now().Unix(), zoomMeetingID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// recurringTypes 定期会议类型，SQL片段
+var recurringTypes = fmt.Sprintf("(%d, %d)", models.MeetingTypeRecurringNoFixed, models.MeetingTypeRecurringFixedTime)
+
+// EndMeeting 标记会议结束；定期会议结束的只是本次会议，仍保持等待状态
+func (s *Store) EndMeeting(zoomMeetingID int64) error {
+	result, err := s.db.Exec(`UPDATE meetings SET status = CASE WHEN type IN `+recurringTypes+` THEN ? ELSE ? END, updated_at = ?
+WHERE zoom_meeting_id = ?`,
+		models.MeetingStatusWaiting, models.MeetingStatusEnded, time.Now().Unix(), zoomMeetingID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ListUserMeetings 获取用户创建或受邀的会议，返回当前页记录与总数
+func (s *Store) ListUserMeetings(filter *models.MyMeetingsFilter) ([]models.MyMeetingItem, int, error) {
+	now := time.Now().Unix()
+	email := strings.ToLower(strings.TrimSpace(filter.Email))
+
+	where := []string{
+		`status != ?`,
+		`(creator_user_id = ? OR (? != '' AND EXISTS (SELECT 1 FROM meeting_invitees i WHERE i.meeting_id = meetings.id AND i.email = ?)))`,
+	}
+	args := []interface{}{models.MeetingStatusDeleted, filter.UserID, email, email}
+
+	order := "start_time DESC, id DESC"
+	switch filter.Scope {
+	case models.MeetingScopeLive:
+		where = append(where, `status = ?`)
+		args = append(args, models.MeetingStatusStarted)
+		order = "start_time ASC, id ASC"
+	case models.MeetingScopeUpcoming:
+		// 定期会议的 start_time 是第一次会议的时间，只要未删除和结束就视为即将开始
+		where = append(where, `status = ? AND (type IN `+recurringTypes+` OR start_time IS NULL OR start_time + duration * 60 >= ?)`)
+		args = append(args, models.MeetingStatusWaiting, now)
+		order = "start_time ASC, id ASC"
+	case models.MeetingScopePast:
+		where = append(where, `(status = ? OR (status = ? AND type NOT IN `+recurringTypes+` AND start_time + duration * 60 < ?))`)
+		args = append(args, models.MeetingStatusEnded, models.MeetingStatusWaiting, now)
+	}
+	if !filter.From.IsZero() {
+		where = append(where, `start_time >= ?`)
+		args = append(args, filter.From.Unix())
+	}
+	if !filter.To.IsZero() {
+		where = append(where, `start_time < ?`)
+		args = append(args, filter.To.Unix())
+	}
+	whereSQL := strings.Join(where, " AND ")
+
+	var total int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM meetings WHERE `+whereSQL, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.PageSize
+	rows, err := s.db.Query(`SELECT `+meetingColumns+` FROM meetings WHERE `+whereSQL+` ORDER BY `+order+` LIMIT ? OFFSET ?`,
+		append(args, filter.PageSize, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := []models.MyMeetingItem{}
+	for rows.Next() {
+		rec, err := scanMeeting(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, models.MyMeetingItem{
+			MeetingRecord: rec,
+			IsCreator:     rec.CreatorUserID == filter.UserID,
+		})
+	}
+	return items, total, rows.Err()
+}
+
+// rowScanner 兼容 *sql.Row 与 *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanMeeting 读取一行会议记录
+func scanMeeting(row rowScanner) (*models.MeetingRecord, error) {
+	var (
+		rec                  models.MeetingRecord
+		startTime            sql.NullInt64
+		settings, recurrence sql.NullString
+		createdAt, updatedAt int64
+	)
+	if err := row.Scan(&rec.ID, &rec.ZoomMeetingID, &rec.UUID, &rec.CreatorUserID, &rec.HostID, &rec.HostEmail,
+		&rec.Topic, &rec.Type, &startTime, &rec.Duration, &rec.Timezone, &rec.JoinURL, &settings, &recurrence,
+		&rec.Status, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	if startTime.Valid {
+		rec.StartTime = time.Unix(startTime.Int64, 0).UTC()
+	}
+	rec.CreatedAt = time.Unix(createdAt, 0).UTC()
+	rec.UpdatedAt = time.Unix(updatedAt, 0).UTC()
+	if settings.Valid {
+		rec.Settings = new(models.MeetingSettings)
+		if err := json.Unmarshal([]byte(settings.String), rec.Settings); err != nil {
+			return nil, err
+		}
+	}
+	if recurrence.Valid {
+		rec.Recurrence = new(models.Recurrence)
+		if err := json.Unmarshal([]byte(recurrence.String), rec.Recurrence); err != nil {
+			return nil, err
+		}
+	}
+	return &rec, nil
+}
+
+// marshalNullable 将可选结构编码为JSON，nil时写入NULL
+func marshalNullable(v interface{}) (sql.NullString, error) {
+	switch val := v.(type) {
+	case *models.MeetingSettings:
+		if val == nil {
+			return sql.NullString{}, nil
+		}
+	case *models.Recurrence:
+		if val == nil {
+			return sql.NullString{}, nil
+		}
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// unixOrNull 零值时间写入NULL
+func unixOrNull(t time.Time) sql.NullInt64 {
+	if t.IsZero() {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: t.Unix(), Valid: true}
+}
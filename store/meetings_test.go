@@ -0,0 +1,241 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"zoom-app-server/models"
+	"zoom-app-server/utils/logger"
+)
+
+func TestMain(m *testing.M) {
+	logger.InitLogger(&logger.LogConfig{Level: "error", Format: "text", Output: "stdout"})
+	os.Exit(m.Run())
+}
+
+// openTestStore 在临时目录中打开数据库
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+// mustSave 保存会议记录，失败时终止测试
+func mustSave(t *testing.T, s *Store, rec *models.MeetingRecord) *models.MeetingRecord {
+	t.Helper()
+	if err := s.SaveMeeting(rec); err != nil {
+		t.Fatalf("SaveMeeting(%d): %v", rec.ZoomMeetingID, err)
+	}
+	return rec
+}
+
+// invitees 生成受邀参会者设置
+func invitees(emails ...string) *models.MeetingSettings {
+	settings := &models.MeetingSettings{}
+	for _, email := range emails {
+		settings.MeetingInvitees = append(settings.MeetingInvitees, models.MeetingInvitee{Email: email})
+	}
+	return settings
+}
+
+func TestOpenAppliesMigrationsOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "test.db")
+	for i := 0; i < 2; i++ {
+		s, err := Open(path)
+		if err != nil {
+			t.Fatalf("Open #%d: %v", i, err)
+		}
+		var count, version int
+		if err := s.db.QueryRow(`SELECT COUNT(*), MAX(version) FROM schema_migrations`).Scan(&count, &version); err != nil {
+			t.Fatalf("read schema_migrations: %v", err)
+		}
+		if count != len(migrations) || version != migrations[len(migrations)-1].version {
+			t.Errorf("Open #%d: %d migrations up to version %d, want %d up to %d",
+				i, count, version, len(migrations), migrations[len(migrations)-1].version)
+		}
+		if err := s.CheckWritable(t.Context()); err != nil {
+			t.Errorf("CheckWritable: %v", err)
+		}
+		s.Close()
+	}
+}
+
+func TestSaveMeetingUpsertsInvitees(t *testing.T) {
+	s := openTestStore(t)
+	inviteeEmails := func(id int64) []string {
+		t.Helper()
+		rows, err := s.db.Query(`SELECT email FROM meeting_invitees WHERE meeting_id = ? ORDER BY email`, id)
+		if err != nil {
+			t.Fatalf("query invitees: %v", err)
+		}
+		defer rows.Close()
+		var emails []string
+		for rows.Next() {
+			var email string
+			if err := rows.Scan(&email); err != nil {
+				t.Fatalf("scan invitee: %v", err)
+			}
+			emails = append(emails, email)
+		}
+		return emails
+	}
+
+	first := mustSave(t, s, &models.MeetingRecord{
+		ZoomMeetingID: 85000000001,
+		CreatorUserID: 1,
+		Topic:         "周会",
+		Type:          models.MeetingTypeScheduled,
+		Settings:      invitees("A@example.com", " b@example.com ", "a@example.com", ""),
+	})
+	if got := inviteeEmails(first.ID); len(got) != 2 || got[0] != "a@example.com" || got[1] != "b@example.com" {
+		t.Errorf("invitees after create = %v, want [a@example.com b@example.com]", got)
+	}
+
+	// 同一会议号再次保存时覆盖记录并替换受邀参会者
+	second := mustSave(t, s, &models.MeetingRecord{
+		ZoomMeetingID: 85000000001,
+		CreatorUserID: 1,
+		Topic:         "周会（改期）",
+		Type:          models.MeetingTypeScheduled,
+		Settings:      invitees("c@example.com"),
+	})
+	if second.ID != first.ID {
+		t.Errorf("re-saved meeting id = %d, want %d", second.ID, first.ID)
+	}
+	if got := inviteeEmails(first.ID); len(got) != 1 || got[0] != "c@example.com" {
+		t.Errorf("invitees after update = %v, want [c@example.com]", got)
+	}
+	rec, err := s.GetMeetingByZoomID(85000000001)
+	if err != nil {
+		t.Fatalf("GetMeetingByZoomID: %v", err)
+	}
+	if rec.Topic != "周会（改期）" || rec.Status != models.MeetingStatusWaiting {
+		t.Errorf("record = %+v, want updated topic and waiting status", rec)
+	}
+
+	// 清空设置后不再有受邀参会者
+	mustSave(t, s, &models.MeetingRecord{ZoomMeetingID: 85000000001, CreatorUserID: 1})
+	if got := inviteeEmails(first.ID); len(got) != 0 {
+		t.Errorf("invitees after clearing settings = %v, want none", got)
+	}
+
+	if _, err := s.GetMeetingByZoomID(1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetMeetingByZoomID(unknown) err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestListUserMeetings(t *testing.T) {
+	s := openTestStore(t)
+	now := time.Now()
+	save := func(id int64, creator int, meetingType int, start time.Time, status string, emails ...string) {
+		t.Helper()
+		mustSave(t, s, &models.MeetingRecord{
+			ZoomMeetingID: id,
+			CreatorUserID: creator,
+			Type:          meetingType,
+			StartTime:     start,
+			Duration:      60,
+			Status:        status,
+			Settings:      invitees(emails...),
+		})
+	}
+	save(1, 1, models.MeetingTypeScheduled, now.Add(24*time.Hour), models.MeetingStatusWaiting)
+	save(2, 1, models.MeetingTypeScheduled, now.Add(-10*time.Minute), models.MeetingStatusStarted)
+	save(3, 1, models.MeetingTypeScheduled, now.Add(-48*time.Hour), models.MeetingStatusEnded)
+	save(4, 1, models.MeetingTypeScheduled, now.Add(-72*time.Hour), models.MeetingStatusWaiting) // 未开始但已过期
+	save(5, 1, models.MeetingTypeRecurringFixedTime, now.Add(-96*time.Hour), models.MeetingStatusWaiting)
+	save(6, 1, models.MeetingTypeScheduled, now.Add(48*time.Hour), models.MeetingStatusDeleted)
+	save(7, 2, models.MeetingTypeScheduled, now.Add(2*time.Hour), models.MeetingStatusWaiting, "user1@example.com")
+	save(8, 2, models.MeetingTypeScheduled, now.Add(3*time.Hour), models.MeetingStatusWaiting, "other@example.com")
+
+	tests := []struct {
+		name      string
+		filter    models.MyMeetingsFilter
+		wantIDs   []int64
+		wantTotal int
+	}{
+		{"creator only", models.MyMeetingsFilter{UserID: 1, Scope: models.MeetingScopeAll, Page: 1, PageSize: 10},
+			[]int64{1, 2, 3, 4, 5}, 5},
+		{"creator and invitee", models.MyMeetingsFilter{UserID: 1, Email: " USER1@example.com", Scope: models.MeetingScopeAll, Page: 1, PageSize: 10},
+			[]int64{1, 7, 2, 3, 4, 5}, 6},
+		{"other user", models.MyMeetingsFilter{UserID: 3, Email: "nobody@example.com", Scope: models.MeetingScopeAll, Page: 1, PageSize: 10},
+			[]int64{}, 0},
+		{"live", models.MyMeetingsFilter{UserID: 1, Scope: models.MeetingScopeLive, Page: 1, PageSize: 10},
+			[]int64{2}, 1},
+		{"upcoming includes recurring", models.MyMeetingsFilter{UserID: 1, Email: "user1@example.com", Scope: models.MeetingScopeUpcoming, Page: 1, PageSize: 10},
+			[]int64{5, 7, 1}, 3},
+		{"past includes expired waiting", models.MyMeetingsFilter{UserID: 1, Scope: models.MeetingScopePast, Page: 1, PageSize: 10},
+			[]int64{3, 4}, 2},
+		{"second page", models.MyMeetingsFilter{UserID: 1, Scope: models.MeetingScopeAll, Page: 2, PageSize: 2},
+			[]int64{3, 4}, 5},
+		{"time range", models.MyMeetingsFilter{UserID: 1, Scope: models.MeetingScopeAll, From: now.Add(-50 * time.Hour), To: now, Page: 1, PageSize: 10},
+			[]int64{2, 3}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, total, err := s.ListUserMeetings(&tt.filter)
+			if err != nil {
+				t.Fatalf("ListUserMeetings: %v", err)
+			}
+			ids := []int64{}
+			for _, item := range items {
+				ids = append(ids, item.ZoomMeetingID)
+				if item.IsCreator != (item.CreatorUserID == tt.filter.UserID) {
+					t.Errorf("meeting %d: IsCreator = %v", item.ZoomMeetingID, item.IsCreator)
+				}
+			}
+			if total != tt.wantTotal || len(ids) != len(tt.wantIDs) {
+				t.Fatalf("got %v (total %d), want %v (total %d)", ids, total, tt.wantIDs, tt.wantTotal)
+			}
+			for i := range ids {
+				if ids[i] != tt.wantIDs[i] {
+					t.Fatalf("got %v, want %v", ids, tt.wantIDs)
+				}
+			}
+		})
+	}
+}
+
+func TestEndMeeting(t *testing.T) {
+	s := openTestStore(t)
+	tests := []struct {
+		name        string
+		meetingType int
+		wantStatus  string
+	}{
+		{"instant", models.MeetingTypeInstant, models.MeetingStatusEnded},
+		{"scheduled", models.MeetingTypeScheduled, models.MeetingStatusEnded},
+		{"recurring without fixed time", models.MeetingTypeRecurringNoFixed, models.MeetingStatusWaiting},
+		{"recurring with fixed time", models.MeetingTypeRecurringFixedTime, models.MeetingStatusWaiting},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := int64(85000000001 + i)
+			mustSave(t, s, &models.MeetingRecord{ZoomMeetingID: id, Type: tt.meetingType, Status: models.MeetingStatusStarted})
+			if err := s.EndMeeting(id); err != nil {
+				t.Fatalf("EndMeeting: %v", err)
+			}
+			rec, err := s.GetMeetingByZoomID(id)
+			if err != nil {
+				t.Fatalf("GetMeetingByZoomID: %v", err)
+			}
+			if rec.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", rec.Status, tt.wantStatus)
+			}
+		})
+	}
+
+	if err := s.EndMeeting(1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("EndMeeting(unknown) err = %v, want ErrNotFound", err)
+	}
+	if err := s.UpdateMeetingStatus(1, models.MeetingStatusStarted); !errors.Is(err, ErrNotFound) {
+		t.Errorf("UpdateMeetingStatus(unknown) err = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,113 @@
+package store
+
+import (
+	"fmt"
+
+	"zoom-app-server/utils/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+// migration 数据库迁移
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// migrations 按版本顺序排列的迁移，已发布的迁移不能修改，只能追加
+var migrations = []migration{
+	{
+		version: 1,
+		name:    "create meetings",
+		sql: `
+CREATE TABLE meetings (
+	id              INTEGER PRIMARY KEY AUTOINCREMENT,
+	zoom_meeting_id INTEGER NOT NULL UNIQUE,
+	uuid            TEXT    NOT NULL DEFAULT '',
+	creator_user_id INTEGER NOT NULL DEFAULT 0,
+	host_id         TEXT    NOT NULL DEFAULT '',
+	host_email      TEXT    NOT NULL DEFAULT '',
+	topic           TEXT    NOT NULL DEFAULT '',
+	type            INTEGER NOT NULL DEFAULT 0,
+	start_time      INTEGER,
+	duration        INTEGER NOT NULL DEFAULT 0,
+	timezone        TEXT    NOT NULL DEFAULT '',
+	join_url        TEXT    NOT NULL DEFAULT '',
+	settings        TEXT,
+	recurrence      TEXT,
+	status          TEXT    NOT NULL,
+	created_at      INTEGER NOT NULL,
+	updated_at      INTEGER NOT NULL
+);
+CREATE INDEX idx_meetings_creator ON meetings (creator_user_id, start_time);
+`,
+	},
+	{
+		version: 2,
+		name:    "create meeting invitees",
+		sql: `
+CREATE TABLE meeting_invitees (
+	meeting_id INTEGER NOT NULL REFERENCES meetings (id) ON DELETE CASCADE,
+	email      TEXT    NOT NULL,
+	PRIMARY KEY (meeting_id, email)
+);
+CREATE INDEX idx_meeting_invitees_email ON meeting_invitees (email);
+`,
+	},
+	{
+		version: 3,
+		name:    "create health checks",
+		sql: `
+CREATE TABLE health_checks (
+	id         INTEGER PRIMARY KEY,
+	checked_at INTEGER NOT NULL
+);
+`,
+	},
+}
+
+// migrate 执行尚未应用的迁移
+func (s *Store) migrate() error {
+	if _, err := s.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+	version    INTEGER PRIMARY KEY,
+	name       TEXT    NOT NULL,
+	applied_at INTEGER NOT NULL
+)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	var current int
+	if err := s.db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(m.sql); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to apply migration %d (%s): %w", m.version, m.name, err)
+		}
+		if _, err := tx.Exec(`INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, strftime('%s', 'now'))`, m.version, m.name); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to record migration %d: %w", m.version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+
+		logger.WithFields(logrus.Fields{
+			"version": m.version,
+			"name":    m.name,
+		}).Info("Applied database migration")
+	}
+
+	return nil
+}
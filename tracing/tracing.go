@@ -0,0 +1,150 @@
+// Package tracing 初始化 OpenTelemetry 链路追踪，并提供 HTTP 与业务调用的埋点工具
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"zoom-app-server/config"
+	"zoom-app-server/utils/route"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName 本服务的 Tracer 名称
+const tracerName = "zoom-app-server"
+
+// 业务属性
+const (
+	AttrMeetingID = attribute.Key("zoom.meeting_id")
+	AttrUserID    = attribute.Key("enduser.id")
+)
+
+// untracedPaths 不需要追踪的探针和指标接口
+var untracedPaths = map[string]bool{
+	"/metrics": true,
+	"/healthz": true,
+	"/readyz":  true,
+}
+
+// Init 设置 W3C Trace Context 传播，并在配置了 OTLP 地址时启用导出
+// 返回的函数用于退出时刷新并关闭导出器
+func Init(ctx context.Context, cfg *config.Config) (func(ctx context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if cfg.OTelExporterEndpoint == "" {
+		return func(ctx context.Context) error { return nil }, nil
+	}
+
+	endpointURL, err := tracesEndpointURL(cfg.OTelExporterEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpointURL))
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+		resource.WithAttributes(attribute.String("service.name", cfg.OTelServiceName)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.OTelSampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// tracesEndpointURL 按 OTEL_EXPORTER_OTLP_ENDPOINT 的规范在基础地址后追加 /v1/traces
+// WithEndpointURL 会原样使用地址中的路径，http://localhost:4318 不处理会导出到 /
+func tracesEndpointURL(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid OTLP endpoint %q: %w", endpoint, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid OTLP endpoint %q: scheme and host are required", endpoint)
+	}
+	path := strings.TrimRight(u.Path, "/")
+	if !strings.HasSuffix(path, "/v1/traces") {
+		path += "/v1/traces"
+	}
+	u.Path = path
+	return u.String(), nil
+}
+
+// Tracer 返回本服务的 Tracer
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Handler 为入站请求创建服务端 span，并从请求头中提取上游的 trace context
+func Handler(next http.Handler) http.Handler {
+	return otelhttp.NewHandler(next, "http.server",
+		otelhttp.WithFilter(func(r *http.Request) bool {
+			return !untracedPaths[r.URL.Path]
+		}),
+	)
+}
+
+// RouteMiddleware 使用路由模板命名 span，并附加路径中的会议ID，需注册在 route.Middleware 之后
+func RouteMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		span := trace.SpanFromContext(r.Context())
+		if tpl := route.Template(r.Context()); tpl != route.Unmatched {
+			span.SetName(r.Method + " " + tpl)
+			span.SetAttributes(attribute.String("http.route", tpl))
+		}
+		if meetingID := mux.Vars(r)["id"]; meetingID != "" {
+			span.SetAttributes(AttrMeetingID.String(meetingID))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Transport 为出站请求创建客户端 span 并注入 trace context
+func Transport(base http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(base)
+}
+
+// SetUserID 在当前 span 上记录 DooTask 用户ID
+func SetUserID(ctx context.Context, userID int) {
+	trace.SpanFromContext(ctx).SetAttributes(AttrUserID.String(strconv.Itoa(userID)))
+}
+
+// SetMeetingID 在当前 span 上记录会议ID
+func SetMeetingID(ctx context.Context, meetingID int64) {
+	trace.SpanFromContext(ctx).SetAttributes(AttrMeetingID.Int64(meetingID))
+}
+
+// EndSpan 记录错误并结束 span
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,27 @@
+package tracing
+
+import "testing"
+
+func TestTracesEndpointURL(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+		wantErr  bool
+	}{
+		{endpoint: "http://localhost:4318", want: "http://localhost:4318/v1/traces"},
+		{endpoint: "http://localhost:4318/", want: "http://localhost:4318/v1/traces"},
+		{endpoint: "https://collector.example.com/otlp", want: "https://collector.example.com/otlp/v1/traces"},
+		{endpoint: "http://localhost:4318/v1/traces", want: "http://localhost:4318/v1/traces"},
+		{endpoint: "localhost:4318", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := tracesEndpointURL(tt.endpoint)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("tracesEndpointURL(%q) error = %v, wantErr %v", tt.endpoint, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("tracesEndpointURL(%q) = %q, want %q", tt.endpoint, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package logger
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// entryKey 请求级日志条目在context中的键
+type entryKey struct{}
+
+// entryHolder 请求级日志条目，允许下游中间件追加字段（如认证后的用户ID）
+type entryHolder struct {
+	mu    sync.Mutex
+	entry *logrus.Entry
+}
+
+// NewContext 在ctx中保存请求级日志条目
+func NewContext(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, entryKey{}, &entryHolder{entry: entry})
+}
+
+// FromContext 返回请求级日志条目，ctx中没有时返回全局日志器的条目
+func FromContext(ctx context.Context) *logrus.Entry {
+	if holder, ok := ctx.Value(entryKey{}).(*entryHolder); ok {
+		holder.mu.Lock()
+		defer holder.mu.Unlock()
+		return holder.entry
+	}
+	return logrus.NewEntry(Logger)
+}
+
+// AddFields 为请求级日志条目追加字段，之后通过 FromContext 获取的条目都会带上这些字段
+func AddFields(ctx context.Context, fields logrus.Fields) {
+	if holder, ok := ctx.Value(entryKey{}).(*entryHolder); ok {
+		holder.mu.Lock()
+		holder.entry = holder.entry.WithFields(fields)
+		holder.mu.Unlock()
+	}
+}
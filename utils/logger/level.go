@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LevelStatus 当前日志级别及临时修改的恢复计划
+type LevelStatus struct {
+	Level       logrus.Level
+	RevertLevel logrus.Level // 到期后恢复的级别，仅在 RevertAt 非零时有效
+	RevertAt    time.Time    // 自动恢复时间，零值表示不会自动恢复
+}
+
+// levelState 运行时修改日志级别的状态
+var levelState struct {
+	mu       sync.Mutex
+	base     logrus.Level
+	revertAt time.Time
+	timer    *time.Timer
+}
+
+// SetLevel 运行时修改日志级别，revertAfter 大于0时到期自动恢复为临时修改前的级别
+// 临时修改期间再次临时修改，到期后仍恢复为最初的级别
+func SetLevel(level logrus.Level, revertAfter time.Duration) LevelStatus {
+	levelState.mu.Lock()
+	defer levelState.mu.Unlock()
+
+	pending := levelState.timer != nil
+	if pending {
+		levelState.timer.Stop()
+		levelState.timer = nil
+	}
+	if !pending {
+		levelState.base = Logger.GetLevel()
+	}
+
+	Logger.SetLevel(level)
+	if revertAfter > 0 {
+		base := levelState.base
+		levelState.revertAt = time.Now().Add(revertAfter)
+		var timer *time.Timer
+		timer = time.AfterFunc(revertAfter, func() {
+			levelState.mu.Lock()
+			defer levelState.mu.Unlock()
+			if levelState.timer != timer {
+				return
+			}
+			levelState.timer = nil
+			levelState.revertAt = time.Time{}
+			Logger.SetLevel(base)
+			Logger.WithField("log_level", base.String()).Info("Log level reverted")
+		})
+		levelState.timer = timer
+	} else {
+		levelState.revertAt = time.Time{}
+	}
+	return currentLevelStatus()
+}
+
+// GetLevelStatus 返回当前日志级别和恢复计划
+func GetLevelStatus() LevelStatus {
+	levelState.mu.Lock()
+	defer levelState.mu.Unlock()
+	return currentLevelStatus()
+}
+
+// currentLevelStatus 调用方需持有 levelState.mu
+func currentLevelStatus() LevelStatus {
+	status := LevelStatus{Level: Logger.GetLevel()}
+	if levelState.timer != nil {
+		status.RevertLevel = levelState.base
+		status.RevertAt = levelState.revertAt
+	}
+	return status
+}
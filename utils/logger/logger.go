@@ -1,24 +1,32 @@
 package logger
 
 import (
+	"io"
 	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var Logger *logrus.Logger
 
+// fileWriter 文件输出的滚动写入器，未输出到文件时为nil
+var fileWriter *lumberjack.Logger
+
 // LogConfig 日志配置
 type LogConfig struct {
 	Level      string // 日志级别: debug, info, warn, error
 	Format     string // 日志格式: json, text
 	Output     string // 输出方式: stdout, file, both
 	FilePath   string // 日志文件路径
-	MaxSize    int    // 单个日志文件最大大小(MB)
-	MaxBackups int    // 保留的旧日志文件数量
-	MaxAge     int    // 保留日志文件的最大天数
+	MaxSize    int    // 单个日志文件最大大小(MB)，超过后滚动
+	MaxBackups int    // 保留的旧日志文件数量，0表示不按数量清理
+	MaxAge     int    // 保留日志文件的最大天数，0表示不按天数清理
+	Compress   bool   // 是否gzip压缩滚动后的旧日志文件
+	// 额外需要屏蔽的正则表达式，内置已屏蔽token、password、signature等敏感字段
+	RedactPatterns []string
 }
 
 // InitLogger 初始化日志器
@@ -40,24 +48,32 @@ func InitLogger(config *LogConfig) {
 	}
 
 	// 设置日志格式
+	var formatter logrus.Formatter
 	if config.Format == "json" {
-		Logger.SetFormatter(&logrus.JSONFormatter{
+		formatter = &logrus.JSONFormatter{
 			TimestampFormat: time.RFC3339,
-		})
+		}
 	} else {
-		Logger.SetFormatter(&logrus.TextFormatter{
+		formatter = &logrus.TextFormatter{
 			FullTimestamp:   true,
 			TimestampFormat: "2006-01-02 15:04:05",
-		})
+		}
+	}
+
+	// 所有输出都先屏蔽敏感信息
+	redactor, err := NewRedactor(config.RedactPatterns)
+	if err != nil {
+		logrus.Fatalf("Failed to set up log redaction: %v", err)
 	}
+	Logger.SetFormatter(&redactingFormatter{Formatter: formatter, redactor: redactor})
 
 	// 设置输出
 	switch config.Output {
 	case "file":
-		setupFileOutput(config)
+		Logger.SetOutput(setupFileOutput(config))
 	case "both":
-		setupFileOutput(config)
 		// 同时输出到控制台和文件
+		Logger.SetOutput(io.MultiWriter(os.Stdout, setupFileOutput(config)))
 	default:
 		// 默认输出到控制台
 		Logger.SetOutput(os.Stdout)
@@ -67,8 +83,8 @@ func InitLogger(config *LogConfig) {
 	Logger.SetReportCaller(true)
 }
 
-// setupFileOutput 设置文件输出
-func setupFileOutput(config *LogConfig) {
+// setupFileOutput 创建按大小滚动、按数量和天数清理的日志文件写入器
+func setupFileOutput(config *LogConfig) io.Writer {
 	if config.FilePath == "" {
 		config.FilePath = "logs/app.log"
 	}
@@ -79,13 +95,31 @@ func setupFileOutput(config *LogConfig) {
 		logrus.Fatalf("Failed to create log directory: %v", err)
 	}
 
-	// 打开日志文件
+	// 提前打开一次日志文件，尽早发现权限等问题
 	logFile, err := os.OpenFile(config.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
 	if err != nil {
 		logrus.Fatalf("Failed to open log file: %v", err)
 	}
+	logFile.Close()
+
+	fileWriter = &lumberjack.Logger{
+		Filename:   config.FilePath,
+		MaxSize:    config.MaxSize,
+		MaxBackups: config.MaxBackups,
+		MaxAge:     config.MaxAge,
+		Compress:   config.Compress,
+		LocalTime:  true,
+	}
+	return fileWriter
+}
 
-	Logger.SetOutput(logFile)
+// Reopen 关闭当前日志文件，下次写入时按原路径重新打开
+// 用于配合外部logrotate：文件被移走后收到SIGHUP时调用
+func Reopen() error {
+	if fileWriter == nil {
+		return nil
+	}
+	return fileWriter.Close()
 }
 
 // GetDefaultConfig 获取默认日志配置
@@ -98,6 +132,7 @@ func GetDefaultConfig() *LogConfig {
 		MaxSize:    100,
 		MaxBackups: 3,
 		MaxAge:     7,
+		Compress:   false,
 	}
 }
 
@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RedactedValue 敏感信息被替换后的值
+const RedactedValue = "[REDACTED]"
+
+// sensitiveKeys 需要整体屏蔽的字段名，比较时忽略大小写并把 - 视为 _
+var sensitiveKeys = []string{
+	"token",
+	"access_token",
+	"refresh_token",
+	"password",
+	"signature",
+	"tel",
+	"client_secret",
+	"authorization",
+	"ip",
+}
+
+// sensitiveSuffixes 以这些后缀结尾的字段名同样屏蔽，如 zak_token、api_secret、last_ip
+var sensitiveSuffixes = []string{"_token", "_secret", "_password", "_ip"}
+
+var (
+	// authSchemePattern Authorization 头中的凭据，如 "Bearer xxx"
+	authSchemePattern = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9\-._~+/]+=*`)
+	// jwtPattern JWT（签名、ZAK、OAuth令牌等）
+	jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	// jsonStringFieldPattern JSON 中的 "key":"value" 形式的敏感字段，匹配完整的字符串（含空格和转义）
+	jsonStringFieldPattern = regexp.MustCompile(`(?i)("` + sensitiveKeyExpr + `"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	// jsonValueFieldPattern JSON 中的 "key":value 形式的非字符串敏感字段，如数字
+	jsonValueFieldPattern = regexp.MustCompile(`(?i)("` + sensitiveKeyExpr + `"\s*:\s*)([^"\s,}\]][^\s,}\]]*)`)
+	// queryParamPattern 查询串或表单中的 key=value 形式的敏感字段
+	queryParamPattern = regexp.MustCompile(`(?i)(\b` + sensitiveKeyExpr + `=)([^&\s"]+)`)
+)
+
+// sensitiveKeyExpr 匹配敏感字段名的正则片段，允许带前缀如 zak_token
+var sensitiveKeyExpr = `(?:[a-z0-9]+_)*(?:` + strings.Join(sensitiveKeys, "|") + `|[a-z0-9]*secret|[a-z0-9]*password)`
+
+// Redactor 屏蔽日志中的敏感信息
+type Redactor struct {
+	patterns []*regexp.Regexp // 额外配置的正则，匹配部分整体替换
+}
+
+// NewRedactor 创建屏蔽器，patterns 为额外需要屏蔽的正则表达式
+func NewRedactor(patterns []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", p, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// String 屏蔽字符串中的凭据、JWT、敏感键值对和配置的正则匹配
+func (r *Redactor) String(s string) string {
+	s = authSchemePattern.ReplaceAllString(s, "${1} "+RedactedValue)
+	s = jwtPattern.ReplaceAllString(s, RedactedValue)
+	s = jsonStringFieldPattern.ReplaceAllString(s, `${1}"`+RedactedValue+`"`)
+	s = jsonValueFieldPattern.ReplaceAllString(s, "${1}"+RedactedValue)
+	s = queryParamPattern.ReplaceAllString(s, "${1}"+RedactedValue)
+	for _, re := range r.patterns {
+		s = re.ReplaceAllString(s, RedactedValue)
+	}
+	return s
+}
+
+// Value 屏蔽日志字段：敏感字段名整体替换，字符串和error按内容屏蔽
+func (r *Redactor) Value(key string, value interface{}) interface{} {
+	if isSensitiveKey(key) {
+		return RedactedValue
+	}
+	switch v := value.(type) {
+	case string:
+		return r.String(v)
+	case error:
+		return r.String(v.Error())
+	case []byte:
+		return r.String(string(v))
+	default:
+		return value
+	}
+}
+
+// isSensitiveKey 判断字段名是否敏感
+func isSensitiveKey(key string) bool {
+	key = strings.ReplaceAll(strings.ToLower(key), "-", "_")
+	for _, k := range sensitiveKeys {
+		if key == k {
+			return true
+		}
+	}
+	for _, suffix := range sensitiveSuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactingFormatter 在格式化前屏蔽日志消息和字段中的敏感信息
+type redactingFormatter struct {
+	logrus.Formatter
+	redactor *Redactor
+}
+
+// Format 屏蔽后交给原格式化器输出，不修改原日志条目
+func (f *redactingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	redacted := *entry
+	redacted.Message = f.redactor.String(entry.Message)
+	redacted.Data = make(logrus.Fields, len(entry.Data))
+	for k, v := range entry.Data {
+		redacted.Data[k] = f.redactor.Value(k, v)
+	}
+	return f.Formatter.Format(&redacted)
+}
@@ -0,0 +1,21 @@
+package response
+
+import "net/http"
+
+// 业务错误码，HTTP状态码之外用于区分具体错误原因
+const (
+	CodeZoomInvalidField    = 40001 // Zoom 请求字段无效
+	CodeZoomRequestFailed   = 40002 // Zoom 拒绝了请求
+	CodeZoomForbidden       = 40301 // Zoom 账号无权限
+	CodeZoomUserNotFound    = 40401 // Zoom 用户不存在
+	CodeZoomMeetingNotFound = 40402 // Zoom 会议不存在
+	CodeZoomRateLimited     = 42901 // Zoom 请求过于频繁
+	CodeZoomAuthFailed      = 50201 // 服务端 Zoom 认证失败
+	CodeZoomUnavailable     = 50202 // Zoom 服务异常
+	CodeZoomTimeout         = 50401 // Zoom 调用超时
+)
+
+// WriteTooManyRequests 写入429错误响应
+func WriteTooManyRequests(w http.ResponseWriter, code int, message string, data ...interface{}) {
+	WriteError(w, http.StatusTooManyRequests, code, message, data...)
+}
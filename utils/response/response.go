@@ -8,16 +8,20 @@ import (
 
 // WriteSuccess 写入成功响应
 func WriteSuccess(w http.ResponseWriter, data interface{}, message ...string) {
+	resp := models.NewSuccessResponse(data, message...)
+	resp.RequestID = w.Header().Get(models.RequestIDHeader)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(models.NewSuccessResponse(data, message...))
+	json.NewEncoder(w).Encode(resp)
 }
 
 // WriteError 写入错误响应
 func WriteError(w http.ResponseWriter, httpStatus int, code int, message string, data ...interface{}) {
+	resp := models.NewErrorResponse(code, message, data...)
+	resp.RequestID = w.Header().Get(models.RequestIDHeader)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(httpStatus)
-	json.NewEncoder(w).Encode(models.NewErrorResponse(code, message, data...))
+	json.NewEncoder(w).Encode(resp)
 }
 
 // WriteBadRequest 写入400错误响应
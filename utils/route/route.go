@@ -0,0 +1,50 @@
+// Package route 在请求上下文中保存 mux 匹配到的路由模板，供指标、访问日志和链路追踪共用，避免重复匹配路由
+package route
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Unmatched 未匹配到路由（404/405）时使用的模板
+const Unmatched = "unmatched"
+
+// holderKey 路由模板在context中的键
+type holderKey struct{}
+
+// holder 路由模板，由路由器内的 Middleware 写入，外层中间件在请求结束后读取
+type holder struct {
+	template string
+}
+
+// Handler 在请求上下文中放入路由模板，需包裹在指标和访问日志中间件之外
+func Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), holderKey{}, &holder{template: Unmatched})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Middleware 通过 router.Use 注册，记录路由器本次匹配到的路由模板
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h, ok := r.Context().Value(holderKey{}).(*holder); ok {
+			if current := mux.CurrentRoute(r); current != nil {
+				if tpl, err := current.GetPathTemplate(); err == nil {
+					h.template = tpl
+				}
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Template 返回当前请求的路由模板，未匹配或未经过 Handler 时返回 Unmatched
+func Template(ctx context.Context) string {
+	if h, ok := ctx.Value(holderKey{}).(*holder); ok {
+		return h.template
+	}
+	return Unmatched
+}
@@ -0,0 +1,46 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestTemplate(t *testing.T) {
+	router := mux.NewRouter()
+	router.Use(Middleware)
+	router.HandleFunc("/api/meetings/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if got := Template(r.Context()); got != "/api/meetings/{id}" {
+			t.Errorf("Template inside handler = %q", got)
+		}
+	}).Methods("GET")
+
+	var got string
+	handler := Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		router.ServeHTTP(w, r)
+		got = Template(r.Context())
+	}))
+
+	tests := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{"GET", "/api/meetings/123", "/api/meetings/{id}"},
+		{"GET", "/api/unknown", Unmatched},
+		{"DELETE", "/api/meetings/123", Unmatched},
+	}
+	for _, tt := range tests {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.path, nil))
+		if got != tt.want {
+			t.Errorf("%s %s: Template = %q, want %q", tt.method, tt.path, got, tt.want)
+		}
+	}
+
+	// 没有经过 Handler 的请求视为未匹配
+	if got := Template(httptest.NewRequest("GET", "/", nil).Context()); got != Unmatched {
+		t.Errorf("Template without Handler = %q, want %q", got, Unmatched)
+	}
+}
@@ -0,0 +1,44 @@
+// Package version 提供构建版本信息，通过 -ldflags "-X" 在构建时注入
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+
+	"zoom-app-server/models"
+)
+
+// 构建时注入，如 go build -ldflags "-X zoom-app-server/version.Version=v1.2.0"
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+// Get 返回构建信息，未注入提交号和构建时间时从Go模块的VCS信息中读取
+func Get() models.BuildInfo {
+	info := models.BuildInfo{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = setting.Value
+				}
+			case "vcs.modified":
+				info.Modified = setting.Value == "true"
+			}
+		}
+	}
+	return info
+}
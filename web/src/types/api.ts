@@ -4,6 +4,7 @@ export interface ApiResponse<T = any> {
   message: string;  // 响应消息
   data: T;         // 响应数据
   success: boolean; // 是否成功
+  request_id?: string; // 请求ID，反馈问题时用于查找日志
 }
 
 // Zoom签名请求
@@ -15,6 +16,9 @@ export interface ZoomSignatureRequest {
 // Zoom签名响应数据
 export interface ZoomSignatureResponse {
   signature: string;
+  role: number; // 实际签发的角色，可能低于请求的角色
+  zak?: string; // 主持人开会所需的ZAK令牌
+  obf?: string; // 代表用户加入会议的OBF令牌
 }
 
 // 配置响应数据